@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"wwlp-tools/pkg/wwlp"
+	"wwlp-tools/pkg/wwlp/cap"
+)
+
+// renderCAPAlerts maps alerts into CAP, applies --min-severity/--event/
+// --since/--dedup-by-identifier, then renders the result as cap-xml, or
+// falls back to the requested JSON/NDJSON format (defaulting to JSON if
+// format isn't one of those either, since CAP has no plain-text rendering).
+func renderCAPAlerts(alerts []wwlp.WeatherAlert, format, minSeverity, event, since string, dedup bool) {
+	capAlerts := cap.FromWeatherAlerts(alerts)
+	if minSeverity != "" {
+		capAlerts = cap.FilterMinSeverity(capAlerts, cap.ParseSeverity(minSeverity))
+	}
+	if event != "" {
+		capAlerts = cap.FilterEvent(capAlerts, event)
+	}
+	if since != "" {
+		capAlerts = cap.FilterSince(capAlerts, since)
+	}
+	if dedup {
+		capAlerts = cap.DedupByIdentifier(capAlerts)
+	}
+
+	if format == "cap-xml" {
+		data, err := cap.MarshalXML(capAlerts)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		os.Stdout.Write(data)
+		return
+	}
+
+	outFormat, err := parseFormat(format)
+	if err != nil {
+		outFormat = formatJSON
+	}
+	items := make([]any, 0, len(capAlerts))
+	for _, a := range capAlerts {
+		items = append(items, a)
+	}
+	writeRecords(outFormat, items)
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+// outputFormat selects how a subcommand renders its results: human-readable
+// text (the default), a single indented JSON array, or newline-delimited
+// JSON records for streaming consumers.
+type outputFormat string
+
+const (
+	formatText   outputFormat = "text"
+	formatJSON   outputFormat = "json"
+	formatNDJSON outputFormat = "ndjson"
+)
+
+func parseFormat(s string) (outputFormat, error) {
+	switch outputFormat(s) {
+	case formatText, formatJSON, formatNDJSON:
+		return outputFormat(s), nil
+	default:
+		return "", fmt.Errorf("unknown format: %s (want text, json, or ndjson)", s)
+	}
+}
+
+// emitJSON writes v as a single indented JSON document.
+func emitJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// emitNDJSON writes one compact JSON record per line.
+func emitNDJSON(w io.Writer, items []any) error {
+	enc := json.NewEncoder(w)
+	for _, item := range items {
+		if err := enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// timestampLayouts are the formats normalizeTimestamp tries, beyond raw
+// epoch seconds/milliseconds, in order to cover the mix of timestamp shapes
+// across WWLP's and the weather alert upstream's payloads.
+var timestampLayouts = []string{
+	time.RFC3339,
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	time.RFC1123,
+	time.RFC1123Z,
+	"Jan 2, 2006 3:04 PM",
+}
+
+// normalizeTimestamp best-effort parses s into RFC3339, trying epoch
+// seconds/milliseconds and a handful of known layouts before giving up.
+// It returns "" rather than an error since callers treat normalization as
+// a best-effort convenience field, not the timestamp of record.
+func normalizeTimestamp(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return ""
+	}
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return epochToTime(n).UTC().Format(time.RFC3339)
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+// epochToTime interprets n as Unix seconds, or milliseconds if it's too
+// large to be a plausible seconds value.
+func epochToTime(n int64) time.Time {
+	if n > 1e12 {
+		return time.UnixMilli(n)
+	}
+	return time.Unix(n, 0)
+}
+
+type articleOut struct {
+	Title string `json:"title"`
+	Link  string `json:"link"`
+}
+
+func articleOutputs(articles []wwlp.Article) []any {
+	out := make([]any, 0, len(articles))
+	for _, a := range articles {
+		out = append(out, articleOut{Title: wwlp.ArticleTitle(a), Link: a.Link})
+	}
+	return out
+}
+
+type headlineListOut struct {
+	Index        int    `json:"index"`
+	Title        string `json:"title"`
+	Provider     string `json:"provider,omitempty"`
+	ArticleCount int    `json:"article_count"`
+}
+
+func headlineListOutputs(lists []wwlp.HeadlineList) []any {
+	out := make([]any, 0, len(lists))
+	for i, hl := range lists {
+		out = append(out, headlineListOut{
+			Index:        i,
+			Title:        hl.Title,
+			Provider:     hl.Provider,
+			ArticleCount: len(hl.Articles),
+		})
+	}
+	return out
+}
+
+type weatherPointOut struct {
+	Temperature  string `json:"temperature"`
+	Phrase       string `json:"phrase"`
+	PrecipChance string `json:"precip_chance,omitempty"`
+}
+
+func weatherPointOutput(p *wwlp.WeatherPoint, units wwlp.Units) weatherPointOut {
+	return weatherPointOut{
+		Temperature:  wwlp.ConvertTemperature(p.Temperature, units),
+		Phrase:       p.Phrase,
+		PrecipChance: p.PrecipChance,
+	}
+}
+
+type threeDayOut struct {
+	Current  *weatherPointOut `json:"current,omitempty"`
+	Tonight  *weatherPointOut `json:"tonight,omitempty"`
+	Tomorrow *weatherPointOut `json:"tomorrow,omitempty"`
+}
+
+func threeDayOutput(t *wwlp.ThreeDayWeather, units wwlp.Units) threeDayOut {
+	var out threeDayOut
+	if t.Current != nil {
+		p := weatherPointOutput(t.Current, units)
+		out.Current = &p
+	}
+	if t.Tonight != nil {
+		p := weatherPointOutput(t.Tonight, units)
+		out.Tonight = &p
+	}
+	if t.Tomorrow != nil {
+		p := weatherPointOutput(t.Tomorrow, units)
+		out.Tomorrow = &p
+	}
+	return out
+}
+
+type hourlyOut struct {
+	Time         string `json:"time"`
+	TimeRFC3339  string `json:"time_rfc3339,omitempty"`
+	Temperature  string `json:"temperature"`
+	PrecipChance string `json:"precip_chance,omitempty"`
+	Humidity     string `json:"humidity,omitempty"`
+	LongPhrase   string `json:"long_phrase"`
+}
+
+func hourlyOutputs(items []wwlp.HourlyForecast, units wwlp.Units, locale wwlp.Locale) []any {
+	out := make([]any, 0, len(items))
+	for _, h := range items {
+		localTime, _ := wwlp.Localize(h.Time, locale)
+		out = append(out, hourlyOut{
+			Time:         localTime,
+			TimeRFC3339:  normalizeTimestamp(h.Time),
+			Temperature:  wwlp.ConvertTemperature(h.Temperature, units),
+			PrecipChance: h.PrecipChance,
+			Humidity:     h.Humidity,
+			LongPhrase:   h.LongPhrase,
+		})
+	}
+	return out
+}
+
+type dailyOut struct {
+	DayOfWeek      string `json:"day_of_week"`
+	Time           string `json:"time"`
+	TimeRFC3339    string `json:"time_rfc3339,omitempty"`
+	MaxTemperature string `json:"max_temperature"`
+	MinTemperature string `json:"min_temperature,omitempty"`
+	PrecipChance   string `json:"precip_chance,omitempty"`
+	ShortPhrase    string `json:"short_phrase"`
+	DayNarrative   string `json:"day_narrative,omitempty"`
+	NightNarrative string `json:"night_narrative,omitempty"`
+}
+
+func dailyOutputs(items []wwlp.DailyForecast, units wwlp.Units, locale wwlp.Locale) []any {
+	out := make([]any, 0, len(items))
+	for _, d := range items {
+		dayOfWeek, _ := wwlp.Localize(d.DayOfWeek, locale)
+		out = append(out, dailyOut{
+			DayOfWeek:      dayOfWeek,
+			Time:           d.Time,
+			TimeRFC3339:    normalizeTimestamp(d.Time),
+			MaxTemperature: wwlp.ConvertTemperature(d.MaxTemperature, units),
+			MinTemperature: wwlp.ConvertTemperature(d.MinTemperature, units),
+			PrecipChance:   d.PrecipChance,
+			ShortPhrase:    d.ShortPhrase,
+			DayNarrative:   d.DayNarrative,
+			NightNarrative: d.NightNarrative,
+		})
+	}
+	return out
+}
+
+type alertOut struct {
+	AlertKey           string `json:"alert_key"`
+	AreaName           string `json:"area_name"`
+	Severity           string `json:"severity"`
+	Description        string `json:"description"`
+	EffectiveTimestamp string `json:"effective_timestamp"`
+	EffectiveRFC3339   string `json:"effective_rfc3339,omitempty"`
+	ExpireTimestamp    string `json:"expire_timestamp"`
+	ExpireRFC3339      string `json:"expire_rfc3339,omitempty"`
+}
+
+func alertOutputs(alerts []wwlp.WeatherAlert) []any {
+	out := make([]any, 0, len(alerts))
+	for _, a := range alerts {
+		area := firstNonEmpty(a.WeatherDetail.AreaName, a.AreaName)
+		desc := firstNonEmpty(a.Description, strings.TrimSpace(a.WeatherDetail.LongDescription))
+		effective := firstNonEmpty(a.WeatherDetail.EffectiveTimestamp, a.EffectiveTimestamp)
+		expires := firstNonEmpty(a.WeatherDetail.ExpireTimestamp, a.ExpireTimestamp)
+		out = append(out, alertOut{
+			AlertKey:           a.AlertKey,
+			AreaName:           area,
+			Severity:           a.Severity,
+			Description:        desc,
+			EffectiveTimestamp: effective,
+			EffectiveRFC3339:   normalizeTimestamp(effective),
+			ExpireTimestamp:    expires,
+			ExpireRFC3339:      normalizeTimestamp(expires),
+		})
+	}
+	return out
+}
+
+type alertMessageOut struct {
+	Content string `json:"content"`
+	URL     string `json:"url,omitempty"`
+}
+
+func alertMessageOutputs(msgs []wwlp.AlertMessage) []any {
+	out := make([]any, 0, len(msgs))
+	for _, m := range msgs {
+		out = append(out, alertMessageOut{Content: m.Content, URL: m.URL})
+	}
+	return out
+}
+
+type forecastDiscussionOut struct {
+	Headline      string   `json:"headline,omitempty"`
+	Authors       []string `json:"authors,omitempty"`
+	DatePublished string   `json:"date_published,omitempty"`
+	DateModified  string   `json:"date_modified,omitempty"`
+	ArticleBody   string   `json:"article_body,omitempty"`
+}
+
+func forecastDiscussionOutput(article *wwlp.ForecastDiscussion) forecastDiscussionOut {
+	return forecastDiscussionOut{
+		Headline:      article.Headline,
+		Authors:       article.Authors,
+		DatePublished: article.DatePublished,
+		DateModified:  article.DateModified,
+		ArticleBody:   wwlp.CleanForecastText(article.ArticleBody),
+	}
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"wwlp-tools/pkg/wwlp"
@@ -15,6 +18,8 @@ func main() {
 		os.Exit(2)
 	}
 
+	registerBackends()
+
 	switch os.Args[1] {
 	case "headlines":
 		headlines(os.Args[2:])
@@ -24,6 +29,8 @@ func main() {
 		weather(os.Args[2:])
 	case "alerts":
 		alerts(os.Args[2:])
+	case "serve":
+		serve(os.Args[2:])
 	default:
 		usage()
 		os.Exit(2)
@@ -38,6 +45,7 @@ Commands:
   headline-lists  List headline list titles and indexes
   weather         Show weather summaries
   alerts          Show alert messages
+  serve           Run a polling HTTP/JSON server
 
 Default input is fetched from WWLP endpoints.
 Use --file for saved JSON or HTML.
@@ -94,31 +102,105 @@ func headlines(args []string) {
 	source := fs.String("source", "top", "Source: top, additional, headline")
 	listIndex := fs.Int("list", 0, "Headline list index (for source=headline)")
 	limit := fs.Int("limit", 0, "Max items (0 means all)")
+	format := fs.String("format", "text", "Output format: text, json, or ndjson")
 	fs.Parse(args)
 
+	outFormat, err := parseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
 	tv := loadTemplateVarsFromArgs(*file, *quiet)
 	articles, err := wwlp.GetArticles(tv, *source, *listIndex)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
+	if *limit > 0 && *limit < len(articles) {
+		articles = articles[:*limit]
+	}
 
-	for i, a := range articles {
-		if *limit > 0 && i >= *limit {
-			break
-		}
+	if outFormat != formatText {
+		writeRecords(outFormat, articleOutputs(articles))
+		return
+	}
+	for _, a := range articles {
 		title := wwlp.ArticleTitle(a)
 		fmt.Printf("%s - %s\n", title, a.Link)
 	}
 }
 
+// writeRecords emits items as a single JSON array (formatJSON) or as
+// newline-delimited JSON records (formatNDJSON) to stdout, exiting on
+// failure the same way the rest of the CLI reports errors.
+func writeRecords(format outputFormat, items []any) {
+	var err error
+	if format == formatNDJSON {
+		err = emitNDJSON(os.Stdout, items)
+	} else {
+		err = emitJSON(os.Stdout, items)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writeRecord emits a single value: an indented JSON document for
+// formatJSON, or one compact JSON line for formatNDJSON. Singular
+// responses (current conditions, a forecast discussion) have nothing to
+// stream, so ndjson here is just a single compact line rather than an
+// array of records.
+func writeRecord(format outputFormat, v any) {
+	var err error
+	if format == formatNDJSON {
+		err = json.NewEncoder(os.Stdout).Encode(v)
+	} else {
+		err = emitJSON(os.Stdout, v)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// limitHourlyItems caps items to limit entries (0 means unlimited), the
+// same bound printHourly applies, so JSON/NDJSON output matches text mode.
+func limitHourlyItems(items []wwlp.HourlyForecast, limit int) []wwlp.HourlyForecast {
+	if limit <= 0 || limit >= len(items) {
+		return items
+	}
+	return items[:limit]
+}
+
+// limitDailyItems is limitHourlyItems for DailyForecast, matching
+// printSevenDay's bound.
+func limitDailyItems(items []wwlp.DailyForecast, limit int) []wwlp.DailyForecast {
+	if limit <= 0 || limit >= len(items) {
+		return items
+	}
+	return items[:limit]
+}
+
 func headlineLists(args []string) {
 	fs := flag.NewFlagSet("headline-lists", flag.ExitOnError)
 	file := fs.String("file", "", "Input JSON file (or - for stdin)")
 	quiet := fs.Bool("quiet-warning", false, "Suppress JSON shape warnings")
+	format := fs.String("format", "text", "Output format: text, json, or ndjson")
 	fs.Parse(args)
 
+	outFormat, err := parseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+
 	tv := loadTemplateVarsFromArgs(*file, *quiet)
+	if outFormat != formatText {
+		writeRecords(outFormat, headlineListOutputs(tv.HeadlineLists))
+		return
+	}
 	for _, line := range wwlp.HeadlineListTitles(tv) {
 		fmt.Println(line)
 	}
@@ -132,19 +214,55 @@ func weather(args []string) {
 	limit := fs.Int("limit", 0, "Max items for hourly or seven-day")
 	short := fs.Bool("short", false, "Short output for seven-day")
 	counties := fs.String("counties", defaultWeatherAlertCounties, "Counties list for weather alerts")
+	provider := fs.String("provider", "wwlp", "Weather backend: wwlp (default) or another registered provider (e.g. nws, metoffice)")
+	location := fs.String("location", "", "Location as lat,lng or a provider-specific place id, for --provider other than wwlp")
+	place := fs.String("place", "", `Free-text place name (e.g. "Northampton, MA"), resolved to a county instead of hand-editing --counties`)
+	format := fs.String("format", "text", "Output format: text, json, or ndjson")
+	units := fs.String("units", "imperial", "Temperature units: imperial, metric, or si")
+	locale := fs.String("locale", "", "Locale for day-of-week/time labels (only en is implemented; others pass through with a warning)")
 	fs.Parse(args)
 
+	outFormat, err := parseFormat(*format)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	u, err := wwlp.ParseUnits(*units)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+	loc := wwlp.ParseLocale(*locale)
+	warnUnsupportedLocale(loc)
+
+	if *place != "" {
+		*counties = resolvePlaceCounties(*place)
+	}
+
 	if *mode == "forecast" {
 		discussion := loadForecastDiscussionFromArgs(*file)
+		if outFormat != formatText {
+			writeRecord(outFormat, forecastDiscussionOutput(discussion))
+			return
+		}
 		printForecastDiscussion(discussion)
 		return
 	}
 	if *mode == "alerts" {
 		alerts := loadWeatherAlertsFromArgs(*file, *counties)
+		if outFormat != formatText {
+			writeRecords(outFormat, alertOutputs(alerts))
+			return
+		}
 		printWeatherAlerts(alerts)
 		return
 	}
 
+	if *provider != "wwlp" {
+		weatherFromProvider(*provider, *location, *mode, *limit, *short, outFormat, u, loc)
+		return
+	}
+
 	tv := loadTemplateVarsFromArgs(*file, *quiet)
 	if tv.Weather == nil {
 		fmt.Fprintln(os.Stderr, "error: weather missing")
@@ -158,23 +276,122 @@ func weather(args []string) {
 			os.Exit(1)
 		}
 		p := tv.Weather.ThreeDay.Current
-		fmt.Printf("Current: %sF %s\n", p.Temperature, p.Phrase)
+		if outFormat != formatText {
+			writeRecord(outFormat, weatherPointOutput(p, u))
+			return
+		}
+		fmt.Printf("Current: %s%s %s\n", wwlp.ConvertTemperature(p.Temperature, u), wwlp.TemperatureSuffix(u), p.Phrase)
 	case "three-day":
 		if tv.Weather.ThreeDay == nil {
 			fmt.Fprintln(os.Stderr, "error: three_day weather missing")
 			os.Exit(1)
 		}
-		printThreeDay(tv.Weather.ThreeDay)
+		if outFormat != formatText {
+			writeRecord(outFormat, threeDayOutput(tv.Weather.ThreeDay, u))
+			return
+		}
+		printThreeDay(tv.Weather.ThreeDay, u)
 	case "hourly":
-		printHourly(tv.Weather.Hourly, *limit)
+		if outFormat != formatText {
+			writeRecords(outFormat, hourlyOutputs(limitHourlyItems(tv.Weather.Hourly, *limit), u, loc))
+			return
+		}
+		printHourly(tv.Weather.Hourly, *limit, u, loc)
 	case "seven-day":
-		printSevenDay(tv.Weather.SevenDay, *limit, *short)
+		if outFormat != formatText {
+			writeRecords(outFormat, dailyOutputs(limitDailyItems(tv.Weather.SevenDay, *limit), u, loc))
+			return
+		}
+		printSevenDay(tv.Weather.SevenDay, *limit, *short, u, loc)
 	default:
 		fmt.Fprintf(os.Stderr, "error: unknown mode: %s\n", *mode)
 		os.Exit(1)
 	}
 }
 
+// warnUnsupportedLocale prints a single stderr warning up front when
+// locale isn't implemented, instead of Localize reporting ok=false on
+// every field it's asked to translate.
+func warnUnsupportedLocale(locale wwlp.Locale) {
+	if _, ok := wwlp.Localize("", locale); !ok {
+		fmt.Fprintf(os.Stderr, "warning: locale %q not implemented; falling back to English\n", locale)
+	}
+}
+
+// resolvePlaceCounties resolves a free-text place name to its NWS county
+// FIPS code via wwlp.LocationResolver, caching the result on disk.
+func resolvePlaceCounties(place string) string {
+	loc, err := wwlp.NewLocationResolver().Resolve(context.Background(), place)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "error: resolve place %q: %v\n", place, err)
+		os.Exit(1)
+	}
+	return loc.CountyFIPS
+}
+
+// parseLocation parses "lat,lng" into a wwlp.Location; anything else is
+// passed through as a provider-specific place id (an NWS zone, a Met
+// Office DataPoint location, ...).
+func parseLocation(s string) wwlp.Location {
+	if lat, lng, ok := strings.Cut(s, ","); ok {
+		if latF, err := strconv.ParseFloat(strings.TrimSpace(lat), 64); err == nil {
+			if lngF, err := strconv.ParseFloat(strings.TrimSpace(lng), 64); err == nil {
+				return wwlp.Location{Lat: latF, Lng: lngF}
+			}
+		}
+	}
+	return wwlp.Location{Place: s}
+}
+
+func weatherFromProvider(providerName, location, mode string, limit int, short bool, format outputFormat, units wwlp.Units, locale wwlp.Locale) {
+	p, ok := wwlp.GetWeatherProvider(providerName)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "error: unknown provider: %s (available: %s)\n", providerName, strings.Join(wwlp.WeatherProviderNames(), ", "))
+		os.Exit(1)
+	}
+	loc := parseLocation(location)
+	ctx := context.Background()
+
+	switch mode {
+	case "current", "three-day":
+		point, err := p.Current(ctx, loc)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if format != formatText {
+			writeRecord(format, weatherPointOutput(point, units))
+			return
+		}
+		fmt.Printf("Current: %s%s %s\n", wwlp.ConvertTemperature(point.Temperature, units), wwlp.TemperatureSuffix(units), point.Phrase)
+	case "hourly":
+		items, err := p.Hourly(ctx, loc, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if format != formatText {
+			writeRecords(format, hourlyOutputs(items, units, locale))
+			return
+		}
+		printHourly(items, limit, units, locale)
+	case "seven-day":
+		items, err := p.Daily(ctx, loc, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+		if format != formatText {
+			writeRecords(format, dailyOutputs(items, units, locale))
+			return
+		}
+		printSevenDay(items, limit, short, units, locale)
+	default:
+		fmt.Fprintf(os.Stderr, "error: unknown mode: %s\n", mode)
+		os.Exit(1)
+	}
+}
+
 func loadForecastDiscussionFromArgs(file string) *wwlp.ForecastDiscussion {
 	var (
 		article *wwlp.ForecastDiscussion
@@ -344,41 +561,47 @@ func firstNonEmpty(values ...string) string {
 	return ""
 }
 
-func printThreeDay(t *wwlp.ThreeDayWeather) {
+func printThreeDay(t *wwlp.ThreeDayWeather, units wwlp.Units) {
+	suffix := wwlp.TemperatureSuffix(units)
 	if t.Current != nil {
-		fmt.Printf("Current: %sF %s\n", t.Current.Temperature, t.Current.Phrase)
+		fmt.Printf("Current: %s%s %s\n", wwlp.ConvertTemperature(t.Current.Temperature, units), suffix, t.Current.Phrase)
 	}
 	if t.Tonight != nil {
 		precip := formatPrecip(t.Tonight.PrecipChance)
-		fmt.Printf("Tonight: %sF %s%s\n", t.Tonight.Temperature, t.Tonight.Phrase, precip)
+		fmt.Printf("Tonight: %s%s %s%s\n", wwlp.ConvertTemperature(t.Tonight.Temperature, units), suffix, t.Tonight.Phrase, precip)
 	}
 	if t.Tomorrow != nil {
 		precip := formatPrecip(t.Tomorrow.PrecipChance)
-		fmt.Printf("Tomorrow: %sF %s%s\n", t.Tomorrow.Temperature, t.Tomorrow.Phrase, precip)
+		fmt.Printf("Tomorrow: %s%s %s%s\n", wwlp.ConvertTemperature(t.Tomorrow.Temperature, units), suffix, t.Tomorrow.Phrase, precip)
 	}
 }
 
-func printHourly(items []wwlp.HourlyForecast, limit int) {
+func printHourly(items []wwlp.HourlyForecast, limit int, units wwlp.Units, locale wwlp.Locale) {
+	suffix := wwlp.TemperatureSuffix(units)
 	for i, h := range items {
 		if limit > 0 && i >= limit {
 			break
 		}
 		precip := formatPrecip(h.PrecipChance)
-		fmt.Printf("%s %sF %s%s\n", h.Time, h.Temperature, h.LongPhrase, precip)
+		localTime, _ := wwlp.Localize(h.Time, locale)
+		fmt.Printf("%s %s%s %s%s\n", localTime, wwlp.ConvertTemperature(h.Temperature, units), suffix, h.LongPhrase, precip)
 	}
 }
 
-func printSevenDay(items []wwlp.DailyForecast, limit int, short bool) {
+func printSevenDay(items []wwlp.DailyForecast, limit int, short bool, units wwlp.Units, locale wwlp.Locale) {
+	suffix := wwlp.TemperatureSuffix(units)
 	for i, d := range items {
 		if limit > 0 && i >= limit {
 			break
 		}
 		precip := formatPrecip(d.PrecipChance)
+		dayOfWeek, _ := wwlp.Localize(d.DayOfWeek, locale)
+		maxTemp := wwlp.ConvertTemperature(d.MaxTemperature, units)
+		minTemp := wwlp.ConvertTemperature(d.MinTemperature, units)
+		fmt.Printf("%s: %s%s/%s%s %s%s\n", dayOfWeek, maxTemp, suffix, minTemp, suffix, d.ShortPhrase, precip)
 		if short || (d.DayNarrative == "" && d.NightNarrative == "") {
-			fmt.Printf("%s: %sF/%sF %s%s\n", d.DayOfWeek, d.MaxTemperature, d.MinTemperature, d.ShortPhrase, precip)
 			continue
 		}
-		fmt.Printf("%s: %sF/%sF %s%s\n", d.DayOfWeek, d.MaxTemperature, d.MinTemperature, d.ShortPhrase, precip)
 		if d.DayNarrative != "" {
 			fmt.Printf("  Day: %s\n", d.DayNarrative)
 		}
@@ -404,10 +627,72 @@ func alerts(args []string) {
 	listTypes := fs.Bool("list-types", false, "List available alert types")
 	weatherAlerts := fs.Bool("weather", false, "Fetch weather alerts from the weather service")
 	counties := fs.String("counties", defaultWeatherAlertCounties, "Counties list for weather alerts")
+	provider := fs.String("provider", "wwlp", "Weather backend: wwlp (default) or another registered provider (e.g. nws, metoffice)")
+	location := fs.String("location", "", "Location as lat,lng or a provider-specific place id, for --provider other than wwlp")
+	alertSources := fs.String("alert-sources", "", "Comma-separated Source backend names (e.g. nws,metno) to fetch alerts from via wwlp.GetSource; multiple names are merged with wwlp.MultiSource and take priority over --provider/--file")
+	place := fs.String("place", "", `Free-text place name (e.g. "Northampton, MA"), resolved to a county instead of hand-editing --counties`)
+	format := fs.String("format", "text", "Output format: text, json, ndjson, or (with --weather) cap-xml")
+	minSeverity := fs.String("min-severity", "", "With --weather, drop CAP alerts below this severity: minor, moderate, severe, extreme")
+	eventFilter := fs.String("event", "", "With --weather, only include alerts whose event matches this substring")
+	since := fs.String("since", "", "With --weather, only include alerts sent at or after this RFC3339 timestamp")
+	dedup := fs.Bool("dedup-by-identifier", false, "With --weather, collapse alerts sharing a CAP identifier+sender, keeping the newest")
 	fs.Parse(args)
 
+	capRequested := *format == "cap-xml" || *minSeverity != "" || *eventFilter != "" || *since != "" || *dedup
+
+	var outFormat outputFormat
+	var err error
+	if *format == "cap-xml" {
+		// cap-xml only makes sense alongside --weather; elsewhere, fall
+		// back to JSON rather than leaving outFormat at its zero value.
+		outFormat = formatJSON
+	} else {
+		outFormat, err = parseFormat(*format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if *place != "" {
+		*counties = resolvePlaceCounties(*place)
+	}
+
 	if *weatherAlerts {
-		alerts := loadWeatherAlertsFromArgs(*file, *counties)
+		var alerts []wwlp.WeatherAlert
+		if *alertSources != "" {
+			alerts, err = alertsFromSources(*alertSources, *counties, *location, *place)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+		} else if *provider != "wwlp" {
+			p, ok := wwlp.GetWeatherProvider(*provider)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "error: unknown provider: %s (available: %s)\n", *provider, strings.Join(wwlp.WeatherProviderNames(), ", "))
+				os.Exit(1)
+			}
+			loc := parseLocation(*location)
+			if *location == "" {
+				loc.Place = *counties
+			}
+			alerts, err = p.Alerts(context.Background(), loc)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "error: %v\n", err)
+				os.Exit(1)
+			}
+		} else {
+			alerts = loadWeatherAlertsFromArgs(*file, *counties)
+		}
+
+		if capRequested {
+			renderCAPAlerts(alerts, *format, *minSeverity, *eventFilter, *since, *dedup)
+			return
+		}
+		if outFormat != formatText {
+			writeRecords(outFormat, alertOutputs(alerts))
+			return
+		}
 		printWeatherAlerts(alerts)
 		return
 	}
@@ -415,8 +700,17 @@ func alerts(args []string) {
 	tv := loadTemplateVarsFromArgs(*file, *quiet)
 
 	if *listTypes || *alertType == "" {
-		for _, t := range wwlp.AlertTypes(tv) {
-			fmt.Println(t)
+		types := wwlp.AlertTypes(tv)
+		if outFormat != formatText {
+			items := make([]any, 0, len(types))
+			for _, t := range types {
+				items = append(items, t)
+			}
+			writeRecords(outFormat, items)
+		} else {
+			for _, t := range types {
+				fmt.Println(t)
+			}
 		}
 		if *alertType == "" {
 			return
@@ -428,6 +722,10 @@ func alerts(args []string) {
 		fmt.Fprintf(os.Stderr, "error: %v\n", err)
 		os.Exit(1)
 	}
+	if outFormat != formatText {
+		writeRecords(outFormat, alertMessageOutputs(msgs))
+		return
+	}
 	for _, m := range msgs {
 		if m.URL != "" {
 			fmt.Printf("%s - %s\n", m.Content, m.URL)
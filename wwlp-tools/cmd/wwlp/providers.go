@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"wwlp-tools/pkg/wwlp"
+	"wwlp-tools/pkg/wwlp/metno"
+	"wwlp-tools/pkg/wwlp/metoffice"
+	"wwlp-tools/pkg/wwlp/nws"
+	"wwlp-tools/pkg/wwlp/openweathermap"
+)
+
+// wwlpUserAgent identifies this CLI to NWS and met.no, both of which
+// require a descriptive User-Agent per their terms of service.
+const wwlpUserAgent = "wwlp-tools (https://github.com/mhlotto/vibrazioni)"
+
+// registerBackends constructs and registers every WeatherProvider/Source
+// backend this binary ships with, beyond the default "wwlp"/"nexstar"
+// ones, so --provider and alerts' --alert-sources can actually select them.
+// NWS and met.no need no credentials and are always registered;
+// OpenWeatherMap and Met Office DataPoint require an API key, so each is
+// only registered when its env var is set.
+func registerBackends() {
+	nwsClient := nws.NewClient(wwlpUserAgent)
+	nws.NewProvider("nws", nwsClient)
+	nws.NewSource("nws", nwsClient)
+
+	metno.NewSource("metno", metno.NewClient(wwlpUserAgent))
+
+	if key := os.Getenv("OPENWEATHERMAP_API_KEY"); key != "" {
+		openweathermap.NewSource("openweathermap", openweathermap.NewClient(key))
+	}
+	if key := os.Getenv("METOFFICE_API_KEY"); key != "" {
+		metoffice.NewProvider("metoffice", metoffice.NewClient(key))
+	}
+}
+
+// alertsFromSources resolves a comma-separated list of registered Source
+// names (see registerBackends) via wwlp.GetSource and fetches alerts
+// through them, merging with wwlp.MultiSource when more than one name is
+// given. location is parsed the same way as --provider's --location
+// (lat,lng or a backend-specific place id); place, if set, overrides it.
+func alertsFromSources(names, counties, location, place string) ([]wwlp.WeatherAlert, error) {
+	var src wwlp.Source
+	parts := strings.Split(names, ",")
+	if len(parts) == 1 {
+		s, ok := wwlp.GetSource(strings.TrimSpace(parts[0]))
+		if !ok {
+			return nil, fmt.Errorf("unknown source: %s (available: %s)", parts[0], strings.Join(wwlp.SourceNames(), ", "))
+		}
+		src = s
+	} else {
+		multi := wwlp.MultiSource{}
+		for _, name := range parts {
+			name = strings.TrimSpace(name)
+			s, ok := wwlp.GetSource(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown source: %s (available: %s)", name, strings.Join(wwlp.SourceNames(), ", "))
+			}
+			multi.Sources = append(multi.Sources, s)
+		}
+		src = multi
+	}
+
+	q := wwlp.Query{Counties: counties}
+	if loc := parseLocation(location); loc.Place == "" {
+		q.Lat, q.Lon = loc.Lat, loc.Lng
+	} else {
+		q.Place = loc.Place
+	}
+	if place != "" {
+		q.Place = place
+	}
+
+	alerts, _, err := src.Fetch(context.Background(), q)
+	if err != nil {
+		return nil, fmt.Errorf("fetch alerts: %w", err)
+	}
+	return alerts, nil
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+// serveCache holds the results of the last poll in which all three
+// upstreams were fetched successfully, so the HTTP handlers below never
+// block on a live request; pollLoop refreshes it on an interval in the
+// background. A poll in which any fetch fails records the error and keeps
+// the previous results of all three.
+type serveCache struct {
+	mu          sync.RWMutex
+	tv          *wwlp.TemplateVars
+	discussion  *wwlp.ForecastDiscussion
+	alerts      []wwlp.WeatherAlert
+	lastUpdated time.Time
+	lastErr     error
+}
+
+func (c *serveCache) snapshot() (*wwlp.TemplateVars, *wwlp.ForecastDiscussion, []wwlp.WeatherAlert, time.Time, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tv, c.discussion, c.alerts, c.lastUpdated, c.lastErr
+}
+
+func (c *serveCache) pollLoop(ctx context.Context, refresh time.Duration, templateVarsURL, discussionURL, counties string) {
+	poll := func() {
+		tv, _, err := wwlp.LoadTemplateVarsURLContext(ctx, templateVarsURL, wwlp.ClientOptions{})
+		if err != nil {
+			c.recordErr(err)
+			return
+		}
+		discussion, err := wwlp.LoadForecastDiscussionURLContext(ctx, discussionURL, wwlp.ClientOptions{})
+		if err != nil {
+			c.recordErr(err)
+			return
+		}
+		alerts, err := wwlp.LoadWeatherAlertsURLContext(ctx, counties, wwlp.ClientOptions{})
+		if err != nil {
+			c.recordErr(err)
+			return
+		}
+
+		c.mu.Lock()
+		c.tv, c.discussion, c.alerts = tv, discussion, alerts
+		c.lastUpdated = time.Now()
+		c.lastErr = nil
+		c.mu.Unlock()
+	}
+
+	poll()
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			poll()
+		}
+	}
+}
+
+func (c *serveCache) recordErr(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastErr = err
+}
+
+func serve(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Listen address")
+	refresh := fs.Duration("refresh", 5*time.Minute, "Poll interval for upstream endpoints")
+	templateVarsURL := fs.String("template-vars-url", defaultTemplateVarsURL, "Template-variables endpoint")
+	discussionURL := fs.String("forecast-discussion-url", defaultForecastDiscussionURL, "Forecast discussion page")
+	counties := fs.String("counties", defaultWeatherAlertCounties, "Counties list for weather alerts")
+	fs.Parse(args)
+
+	cache := &serveCache{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go cache.pollLoop(ctx, *refresh, *templateVarsURL, *discussionURL, *counties)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", cache.handleHealthz)
+	mux.HandleFunc("/api/headlines", cache.handleHeadlines)
+	mux.HandleFunc("/api/weather/current", cache.handleWeatherCurrent)
+	mux.HandleFunc("/api/weather/hourly", cache.handleWeatherHourly)
+	mux.HandleFunc("/api/weather/seven-day", cache.handleWeatherSevenDay)
+	mux.HandleFunc("/api/forecast-discussion", cache.handleForecastDiscussion)
+	mux.HandleFunc("/api/alerts", cache.handleAlerts)
+	mux.HandleFunc("/", cache.handleIndex)
+
+	fmt.Fprintf(os.Stderr, "serving on %s (refresh every %s)\n", *addr, *refresh)
+	if err := http.ListenAndServe(*addr, mux); err != nil {
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func (c *serveCache) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	_, _, _, lastUpdated, lastErr := c.snapshot()
+	status := http.StatusOK
+	staleSeconds := 0.0
+	if lastUpdated.IsZero() {
+		status = http.StatusServiceUnavailable
+	} else {
+		staleSeconds = time.Since(lastUpdated).Seconds()
+	}
+	errMsg := ""
+	if lastErr != nil {
+		errMsg = lastErr.Error()
+	}
+	writeJSON(w, status, map[string]any{
+		"stale_seconds": staleSeconds,
+		"last_updated":  lastUpdated,
+		"last_error":    errMsg,
+	})
+}
+
+func (c *serveCache) handleHeadlines(w http.ResponseWriter, r *http.Request) {
+	tv, _, _, _, err := c.snapshot()
+	if err := requireCache(w, tv, err); err != nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, tv)
+}
+
+func (c *serveCache) handleWeatherCurrent(w http.ResponseWriter, r *http.Request) {
+	tv, _, _, _, err := c.snapshot()
+	if requireCache(w, tv, err) != nil {
+		return
+	}
+	if tv.Weather == nil || tv.Weather.ThreeDay == nil {
+		http.Error(w, "current weather missing", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, tv.Weather.ThreeDay)
+}
+
+func (c *serveCache) handleWeatherHourly(w http.ResponseWriter, r *http.Request) {
+	tv, _, _, _, err := c.snapshot()
+	if requireCache(w, tv, err) != nil {
+		return
+	}
+	if tv.Weather == nil {
+		http.Error(w, "weather missing", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, tv.Weather.Hourly)
+}
+
+func (c *serveCache) handleWeatherSevenDay(w http.ResponseWriter, r *http.Request) {
+	tv, _, _, _, err := c.snapshot()
+	if requireCache(w, tv, err) != nil {
+		return
+	}
+	if tv.Weather == nil {
+		http.Error(w, "weather missing", http.StatusNotFound)
+		return
+	}
+	writeJSON(w, http.StatusOK, tv.Weather.SevenDay)
+}
+
+func (c *serveCache) handleForecastDiscussion(w http.ResponseWriter, r *http.Request) {
+	_, discussion, _, _, err := c.snapshot()
+	if discussion == nil {
+		http.Error(w, errOrMissing(err, "forecast discussion missing"), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, http.StatusOK, discussion)
+}
+
+func (c *serveCache) handleAlerts(w http.ResponseWriter, r *http.Request) {
+	_, _, alerts, lastUpdated, err := c.snapshot()
+	if lastUpdated.IsZero() {
+		http.Error(w, errOrMissing(err, "alerts not yet fetched"), http.StatusServiceUnavailable)
+		return
+	}
+	writeJSON(w, http.StatusOK, alerts)
+}
+
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html><head><title>wwlp serve</title></head><body>
+<h1>Current</h1>
+{{with .ThreeDay}}{{with .Current}}<p>{{.Temperature}}F {{.Phrase}}</p>{{end}}{{end}}
+<h1>Hourly</h1>
+<ul>{{range .Hourly}}<li>{{.Time}} {{.Temperature}}F {{.LongPhrase}}</li>{{end}}</ul>
+<h1>Seven Day</h1>
+<ul>{{range .SevenDay}}<li>{{.DayOfWeek}}: {{.MaxTemperature}}F/{{.MinTemperature}}F {{.ShortPhrase}}</li>{{end}}</ul>
+</body></html>`))
+
+func (c *serveCache) handleIndex(w http.ResponseWriter, r *http.Request) {
+	tv, _, _, _, err := c.snapshot()
+	if requireCache(w, tv, err) != nil {
+		return
+	}
+	if tv.Weather == nil {
+		http.Error(w, "weather missing", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = indexTemplate.Execute(w, tv.Weather)
+}
+
+func requireCache(w http.ResponseWriter, tv *wwlp.TemplateVars, err error) error {
+	if tv == nil {
+		msg := errOrMissing(err, "no data fetched yet")
+		http.Error(w, msg, http.StatusServiceUnavailable)
+		return errors.New(msg)
+	}
+	return nil
+}
+
+func errOrMissing(err error, missing string) string {
+	if err != nil {
+		return err.Error()
+	}
+	return missing
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,266 @@
+// Package cap maps wwlp.WeatherAlert records (sourced from either the
+// Nexstar/WWLP feed or, via the nws package, api.weather.gov) into the
+// Common Alerting Protocol v1.2 message shape
+// (https://docs.oasis-open.org/emergency/cap/v1.2/CAP-v1.2.html), so callers
+// that want a standard, severity-ranked representation don't have to parse
+// WeatherAlertPayload themselves.
+package cap
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+const xmlNamespace = "urn:oasis:names:tc:emergency:cap:1.2"
+
+// Severity is CAP's ranked severity scale.
+type Severity string
+
+const (
+	SeverityExtreme  Severity = "Extreme"
+	SeveritySevere   Severity = "Severe"
+	SeverityModerate Severity = "Moderate"
+	SeverityMinor    Severity = "Minor"
+	SeverityUnknown  Severity = "Unknown"
+)
+
+var severityRank = map[Severity]int{
+	SeverityExtreme:  4,
+	SeveritySevere:   3,
+	SeverityModerate: 2,
+	SeverityMinor:    1,
+	SeverityUnknown:  0,
+}
+
+// ParseSeverity maps a case-insensitive severity name (as accepted by the
+// CLI's --min-severity flag) to a Severity, defaulting to SeverityUnknown
+// for anything it doesn't recognize.
+func ParseSeverity(s string) Severity {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "extreme":
+		return SeverityExtreme
+	case "severe":
+		return SeveritySevere
+	case "moderate":
+		return SeverityModerate
+	case "minor":
+		return SeverityMinor
+	default:
+		return SeverityUnknown
+	}
+}
+
+func (s Severity) rank() int {
+	return severityRank[s]
+}
+
+// AtLeast reports whether s meets or exceeds min on CAP's severity scale.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.rank() >= min.rank()
+}
+
+// Alert is a CAP v1.2 message, trimmed to the fields this module's callers
+// need: identification/dedup keys at the top level, and the hazard details
+// in one or more Info blocks.
+type Alert struct {
+	XMLName    xml.Name `xml:"alert" json:"-"`
+	Xmlns      string   `xml:"xmlns,attr" json:"-"`
+	Identifier string   `xml:"identifier" json:"identifier"`
+	Sender     string   `xml:"sender" json:"sender"`
+	Sent       string   `xml:"sent" json:"sent"`
+	Status     string   `xml:"status" json:"status"`
+	MsgType    string   `xml:"msgType" json:"msg_type"`
+	Scope      string   `xml:"scope" json:"scope"`
+	Info       []Info   `xml:"info" json:"info"`
+}
+
+type Info struct {
+	Category    string   `xml:"category" json:"category"`
+	Event       string   `xml:"event" json:"event"`
+	Urgency     string   `xml:"urgency" json:"urgency"`
+	Severity    Severity `xml:"severity" json:"severity"`
+	Certainty   string   `xml:"certainty" json:"certainty"`
+	Effective   string   `xml:"effective,omitempty" json:"effective,omitempty"`
+	Expires     string   `xml:"expires,omitempty" json:"expires,omitempty"`
+	Headline    string   `xml:"headline,omitempty" json:"headline,omitempty"`
+	Description string   `xml:"description,omitempty" json:"description,omitempty"`
+	Instruction string   `xml:"instruction,omitempty" json:"instruction,omitempty"`
+	Area        Area     `xml:"area" json:"area"`
+}
+
+type Area struct {
+	AreaDesc string `xml:"areaDesc" json:"area_desc"`
+	Polygon  string `xml:"polygon,omitempty" json:"polygon,omitempty"`
+	Geocode  string `xml:"geocode,omitempty" json:"geocode,omitempty"`
+}
+
+// FromWeatherAlert maps a wwlp.WeatherAlert into a CAP Alert with a
+// single Info block, parsing the feed's embedded WeatherAlertPayload for
+// fields CAP expects that aren't already top-level on WeatherAlert. Status,
+// MsgType, and Scope are fixed at "Actual"/"Alert"/"Public" since neither
+// the Nexstar feed nor NWS's GeoJSON distinguishes test/update/cancel
+// messages the way a full CAP feed would.
+func FromWeatherAlert(a wwlp.WeatherAlert) Alert {
+	payload, _ := wwlp.ParseWeatherAlertPayload(a.WeatherDetail.Payload)
+
+	areaName := firstNonEmpty(a.WeatherDetail.AreaName, a.AreaName)
+	desc := firstNonEmpty(a.Description, strings.TrimSpace(a.WeatherDetail.LongDescription))
+	effective := firstNonEmpty(a.WeatherDetail.EffectiveTimestamp, a.EffectiveTimestamp)
+	expires := firstNonEmpty(a.WeatherDetail.ExpireTimestamp, a.ExpireTimestamp)
+	headline := a.WeatherDetail.AlertType
+	urgency, certainty := "Unknown", "Unknown"
+	if payload != nil {
+		urgency = firstNonEmpty(payload.Urgency, urgency)
+		certainty = firstNonEmpty(payload.Certainty, certainty)
+		headline = firstNonEmpty(payload.HeadlineText, payload.HeadlineTextAlt, headline)
+	}
+
+	return Alert{
+		Xmlns:      xmlNamespace,
+		Identifier: a.AlertKey,
+		Sender:     "wwlp",
+		Sent:       firstNonEmpty(a.CreateTimestamp, effective),
+		Status:     "Actual",
+		MsgType:    "Alert",
+		Scope:      "Public",
+		Info: []Info{{
+			Category:    "Met",
+			Event:       firstNonEmpty(a.Phenomena, a.WeatherDetail.AlertType),
+			Urgency:     urgency,
+			Severity:    ParseSeverity(a.Severity),
+			Certainty:   certainty,
+			Effective:   effective,
+			Expires:     expires,
+			Headline:    headline,
+			Description: desc,
+			Area: Area{
+				AreaDesc: areaName,
+				Geocode:  a.AreaID,
+			},
+		}},
+	}
+}
+
+// FromWeatherAlerts maps each alert via FromWeatherAlert.
+func FromWeatherAlerts(alerts []wwlp.WeatherAlert) []Alert {
+	out := make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		out = append(out, FromWeatherAlert(a))
+	}
+	return out
+}
+
+// maxSeverity returns the highest Severity across a's Info blocks.
+func (a Alert) maxSeverity() Severity {
+	best := SeverityUnknown
+	for _, info := range a.Info {
+		if info.Severity.rank() > best.rank() {
+			best = info.Severity
+		}
+	}
+	return best
+}
+
+// FilterMinSeverity keeps alerts whose highest Info.Severity is at least
+// min on CAP's Extreme > Severe > Moderate > Minor > Unknown scale.
+func FilterMinSeverity(alerts []Alert, min Severity) []Alert {
+	out := make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if a.maxSeverity().AtLeast(min) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// FilterEvent keeps alerts with at least one Info.Event containing event,
+// case-insensitively (so "flood" matches "Flood Warning").
+func FilterEvent(alerts []Alert, event string) []Alert {
+	if event == "" {
+		return alerts
+	}
+	out := make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		for _, info := range a.Info {
+			if strings.Contains(strings.ToLower(info.Event), strings.ToLower(event)) {
+				out = append(out, a)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// FilterSince keeps alerts whose Sent is lexically >= since. Both are
+// expected to be RFC3339 timestamps, which sort correctly as plain strings
+// when expressed in the same timezone offset.
+func FilterSince(alerts []Alert, since string) []Alert {
+	if since == "" {
+		return alerts
+	}
+	out := make([]Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if a.Sent >= since {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// DedupByIdentifier collapses alerts sharing the same (Identifier, Sender)
+// key, keeping only the one with the lexically newest Sent — the shape
+// repeated polling (e.g. via SubscribeWeatherAlerts) needs when merging
+// alerts observed across multiple fetches.
+func DedupByIdentifier(alerts []Alert) []Alert {
+	type key struct{ identifier, sender string }
+	latest := make(map[key]Alert)
+	order := make([]key, 0, len(alerts))
+	for _, a := range alerts {
+		k := key{a.Identifier, a.Sender}
+		existing, ok := latest[k]
+		if !ok {
+			order = append(order, k)
+			latest[k] = a
+			continue
+		}
+		if a.Sent > existing.Sent {
+			latest[k] = a
+		}
+	}
+	out := make([]Alert, 0, len(order))
+	for _, k := range order {
+		out = append(out, latest[k])
+	}
+	return out
+}
+
+// MarshalXML renders alerts as a sequence of CAP <alert> documents. CAP has
+// no standard wrapper element for a batch, so each alert is emitted as its
+// own XML document, one after another.
+func MarshalXML(alerts []Alert) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, a := range alerts {
+		a.Xmlns = xmlNamespace
+		buf.WriteString(xml.Header)
+		data, err := xml.MarshalIndent(a, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("marshal alert %s: %w", a.Identifier, err)
+		}
+		buf.Write(data)
+		buf.WriteString("\n")
+	}
+	return buf.Bytes(), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,66 @@
+package cap
+
+import (
+	"strings"
+	"testing"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+func TestFromWeatherAlertMapsSeverityAndArea(t *testing.T) {
+	a := wwlp.WeatherAlert{
+		AlertKey:        "NWS-123",
+		Severity:        "Severe",
+		Phenomena:       "Flood Warning",
+		AreaName:        "Hampden, MA",
+		CreateTimestamp: "2026-07-20T10:00:00Z",
+	}
+	alert := FromWeatherAlert(a)
+	if alert.Identifier != "NWS-123" {
+		t.Fatalf("identifier = %q, want NWS-123", alert.Identifier)
+	}
+	if len(alert.Info) != 1 {
+		t.Fatalf("expected exactly one Info block, got %d", len(alert.Info))
+	}
+	if alert.Info[0].Severity != SeveritySevere {
+		t.Fatalf("severity = %q, want Severe", alert.Info[0].Severity)
+	}
+	if alert.Info[0].Area.AreaDesc != "Hampden, MA" {
+		t.Fatalf("area desc = %q", alert.Info[0].Area.AreaDesc)
+	}
+}
+
+func TestFilterMinSeverityDropsBelowThreshold(t *testing.T) {
+	alerts := []Alert{
+		FromWeatherAlert(wwlp.WeatherAlert{AlertKey: "minor", Severity: "Minor"}),
+		FromWeatherAlert(wwlp.WeatherAlert{AlertKey: "extreme", Severity: "Extreme"}),
+	}
+	filtered := FilterMinSeverity(alerts, SeveritySevere)
+	if len(filtered) != 1 || filtered[0].Identifier != "extreme" {
+		t.Fatalf("expected only the extreme alert to survive, got %+v", filtered)
+	}
+}
+
+func TestDedupByIdentifierKeepsNewestSent(t *testing.T) {
+	alerts := []Alert{
+		{Identifier: "a", Sender: "wwlp", Sent: "2026-07-20T10:00:00Z"},
+		{Identifier: "a", Sender: "wwlp", Sent: "2026-07-20T12:00:00Z"},
+	}
+	deduped := DedupByIdentifier(alerts)
+	if len(deduped) != 1 || deduped[0].Sent != "2026-07-20T12:00:00Z" {
+		t.Fatalf("expected one alert with the newest Sent, got %+v", deduped)
+	}
+}
+
+func TestMarshalXMLIncludesNamespaceAndIdentifier(t *testing.T) {
+	data, err := MarshalXML([]Alert{FromWeatherAlert(wwlp.WeatherAlert{AlertKey: "abc", Severity: "Moderate"})})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), xmlNamespace) {
+		t.Fatalf("expected namespace in output: %s", data)
+	}
+	if !strings.Contains(string(data), "<identifier>abc</identifier>") {
+		t.Fatalf("expected identifier element in output: %s", data)
+	}
+}
@@ -2,6 +2,7 @@ package wwlp
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"html"
@@ -86,6 +87,29 @@ func LoadForecastDiscussionURL(url string) (*ForecastDiscussion, error) {
 	return LoadForecastDiscussion(resp.Body)
 }
 
+// LoadForecastDiscussionURLContext is LoadForecastDiscussionURL with a
+// caller-supplied context and ClientOptions (timeout, retries, backoff,
+// transport, extra headers), so request-scoped pipelines can cancel or
+// bound the fetch.
+func LoadForecastDiscussionURLContext(ctx context.Context, url string, opts ClientOptions) (*ForecastDiscussion, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := doRequest(ctx, req, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("http status: %s", resp.Status)
+	}
+	return LoadForecastDiscussion(resp.Body)
+}
+
 func extractJSONLDBlocks(data []byte) []string {
 	matches := jsonLDScriptRe.FindAllSubmatch(data, -1)
 	if len(matches) == 0 {
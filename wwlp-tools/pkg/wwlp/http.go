@@ -0,0 +1,125 @@
+package wwlp
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// defaultTransport is shared across requests made through ClientOptions so
+// repeated calls (e.g. from a SubscribeWeatherAlerts poll loop) reuse
+// connections instead of paying a fresh TLS handshake each time.
+var defaultTransport = &http.Transport{
+	MaxIdleConns:        100,
+	MaxIdleConnsPerHost: 10,
+	IdleConnTimeout:     90 * time.Second,
+}
+
+// ClientOptions configures the *Context loader variants: how long a single
+// attempt may take, how many times to retry a transient failure, the
+// backoff between retries, and any extra headers or http.Client override.
+type ClientOptions struct {
+	// Timeout is the per-attempt request timeout. Defaults to 15s.
+	Timeout time.Duration
+	// RetryCount is how many additional attempts to make after the first
+	// one fails with a retryable status (429, 503) or network error.
+	// Defaults to 0 (no retries).
+	RetryCount int
+	// BackoffBase is the base delay for exponential backoff between
+	// retries (doubled each attempt, with jitter). Defaults to 500ms.
+	BackoffBase time.Duration
+	// HTTPClient overrides the client used to make requests. If nil, one
+	// is built from Timeout and the shared pooled transport.
+	HTTPClient *http.Client
+	// Headers are merged into each request, overriding any default the
+	// loader would otherwise set for the same key.
+	Headers http.Header
+}
+
+func (o ClientOptions) client() *http.Client {
+	if o.HTTPClient != nil {
+		return o.HTTPClient
+	}
+	timeout := o.Timeout
+	if timeout <= 0 {
+		timeout = 15 * time.Second
+	}
+	return &http.Client{Timeout: timeout, Transport: defaultTransport}
+}
+
+func (o ClientOptions) backoffBase() time.Duration {
+	if o.BackoffBase > 0 {
+		return o.BackoffBase
+	}
+	return 500 * time.Millisecond
+}
+
+// doRequest issues req with ctx attached, retrying on 429/503 and network
+// errors up to opts.RetryCount times with exponential backoff and jitter,
+// honoring a Retry-After header when the upstream sends one.
+func doRequest(ctx context.Context, req *http.Request, opts ClientOptions) (*http.Response, error) {
+	for k, v := range opts.Headers {
+		req.Header[k] = v
+	}
+	req = req.WithContext(ctx)
+	client := opts.client()
+
+	var lastErr error
+	for attempt := 0; attempt <= opts.RetryCount; attempt++ {
+		resp, err := client.Do(req)
+		var delay time.Duration
+		switch {
+		case err != nil:
+			lastErr = fmt.Errorf("http get: %w", err)
+			delay = backoffDelay(opts.backoffBase(), attempt)
+		case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable:
+			lastErr = fmt.Errorf("http status: %s", resp.Status)
+			delay = retryAfterDelay(resp.Header, opts.backoffBase(), attempt)
+			resp.Body.Close()
+		default:
+			return resp, nil
+		}
+
+		if attempt == opts.RetryCount {
+			break
+		}
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, err
+		}
+	}
+	return nil, lastErr
+}
+
+func retryAfterDelay(header http.Header, base time.Duration, attempt int) time.Duration {
+	if ra := header.Get("Retry-After"); ra != "" {
+		if secs, err := strconv.Atoi(ra); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(ra); err == nil {
+			if d := time.Until(t); d > 0 {
+				return d
+			}
+		}
+	}
+	return backoffDelay(base, attempt)
+}
+
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	delay := base << attempt
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return delay + jitter
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,23 @@
+package wwlp
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterDelayUsesHeaderSeconds(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "2")
+	got := retryAfterDelay(header, 500*time.Millisecond, 0)
+	if got != 2*time.Second {
+		t.Fatalf("expected 2s, got %s", got)
+	}
+}
+
+func TestRetryAfterDelayFallsBackToBackoff(t *testing.T) {
+	got := retryAfterDelay(http.Header{}, 100*time.Millisecond, 1)
+	if got < 200*time.Millisecond {
+		t.Fatalf("expected at least doubled base delay, got %s", got)
+	}
+}
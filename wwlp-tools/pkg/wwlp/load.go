@@ -1,12 +1,12 @@
 package wwlp
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"strings"
 	"time"
 )
 
@@ -50,6 +50,28 @@ func LoadTemplateVarsURL(url string) (*TemplateVars, []string, error) {
 	return LoadTemplateVars(resp.Body)
 }
 
+// LoadTemplateVarsURLContext is LoadTemplateVarsURL with a caller-supplied
+// context and ClientOptions (timeout, retries, backoff, transport, extra
+// headers), so request-scoped pipelines can cancel or bound the fetch.
+func LoadTemplateVarsURLContext(ctx context.Context, url string, opts ClientOptions) (*TemplateVars, []string, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", defaultUserAgent)
+
+	resp, err := doRequest(ctx, req, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, nil, fmt.Errorf("http status: %s", resp.Status)
+	}
+	return LoadTemplateVars(resp.Body)
+}
+
 func LoadTemplateVarsBytes(data []byte) (*TemplateVars, []string, error) {
 	warnings := ValidateTemplateVarsShape(data)
 
@@ -60,62 +82,18 @@ func LoadTemplateVarsBytes(data []byte) (*TemplateVars, []string, error) {
 	return &tv, warnings, nil
 }
 
+// ValidateTemplateVarsShape is a thin wrapper over
+// ValidateTemplateVarsAgainstSchema (using the embedded default schema)
+// that keeps returning a flat slice of warning strings, for callers that
+// predate the structured ValidationIssue API.
 func ValidateTemplateVarsShape(data []byte) []string {
-	var raw map[string]json.RawMessage
-	if err := json.Unmarshal(data, &raw); err != nil {
+	issues, err := ValidateTemplateVarsAgainstSchema(data, nil)
+	if err != nil {
 		return []string{fmt.Sprintf("unable to inspect JSON shape: %v", err)}
 	}
-
-	required := []struct {
-		key      string
-		jsonType string
-	}{
-		{key: "top_stories", jsonType: "object"},
-		{key: "additional_top_stories", jsonType: "object"},
-		{key: "headline_lists", jsonType: "array"},
-		{key: "weather", jsonType: "object"},
-		{key: "alert_banners", jsonType: "object"},
-	}
-
-	var warnings []string
-	for _, r := range required {
-		msg := raw[r.key]
-		if len(msg) == 0 {
-			warnings = append(warnings, fmt.Sprintf("missing key: %s", r.key))
-			continue
-		}
-		t := jsonType(msg)
-		if t == "null" {
-			warnings = append(warnings, fmt.Sprintf("key is null: %s", r.key))
-			continue
-		}
-		if t != r.jsonType {
-			warnings = append(warnings, fmt.Sprintf("unexpected type for %s: %s", r.key, t))
-		}
+	warnings := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		warnings = append(warnings, issue.Message)
 	}
 	return warnings
 }
-
-func jsonType(raw json.RawMessage) string {
-	s := strings.TrimSpace(string(raw))
-	if s == "" {
-		return "empty"
-	}
-	switch s[0] {
-	case '{':
-		return "object"
-	case '[':
-		return "array"
-	case '"':
-		return "string"
-	case 't', 'f':
-		return "bool"
-	case 'n':
-		return "null"
-	default:
-		if (s[0] >= '0' && s[0] <= '9') || s[0] == '-' {
-			return "number"
-		}
-	}
-	return "unknown"
-}
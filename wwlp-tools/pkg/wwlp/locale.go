@@ -0,0 +1,33 @@
+package wwlp
+
+// Locale selects the language DailyForecast.DayOfWeek and
+// HourlyForecast.Time labels render in.
+type Locale string
+
+// DefaultLocale is WWLP's and every backend's native language: the day
+// names, "Tonight"/"Tomorrow", and clock strings they return are already
+// English text, not a code to be translated.
+const DefaultLocale Locale = "en"
+
+// ParseLocale returns DefaultLocale for an empty --locale flag value, or
+// s unchanged otherwise; it never fails, since an unrecognized locale
+// falls back to English via Localize rather than being rejected outright.
+func ParseLocale(s string) Locale {
+	if s == "" {
+		return DefaultLocale
+	}
+	return Locale(s)
+}
+
+// Localize renders an English day-of-week or time label for locale. Only
+// "en" is implemented: real translation would need a data-backed i18n
+// library (golang.org/x/text/language is the obvious choice), and this
+// module has zero external dependencies. Other locales pass s through
+// unchanged and report ok=false, so the caller can warn once up front
+// instead of once per field.
+func Localize(s string, locale Locale) (text string, ok bool) {
+	if locale == "" || locale == DefaultLocale {
+		return s, true
+	}
+	return s, false
+}
@@ -0,0 +1,232 @@
+package wwlp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LocationResolver turns a free-text place name ("Springfield, MA" or a
+// ZIP) into a strongly-typed Location, so callers don't need to hand-edit
+// a FIPS county list. It geocodes via Nominatim and resolves the covering
+// NWS forecast zone/county via api.weather.gov/points, caching results on
+// disk so repeated invocations don't re-hit either service.
+type LocationResolver struct {
+	HTTPClient *http.Client
+	UserAgent  string
+	// CacheFile overrides where resolved names are persisted. Defaults to
+	// $XDG_CACHE_HOME/wwlp/locations.json (or the OS equivalent).
+	CacheFile string
+
+	mu    sync.Mutex
+	cache map[string]Location
+}
+
+// NewLocationResolver returns a resolver using the OS cache directory and
+// a default identifying User-Agent (required by both Nominatim's and
+// NWS's usage policies).
+func NewLocationResolver() *LocationResolver {
+	return &LocationResolver{
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+		UserAgent:  defaultUserAgent,
+	}
+}
+
+func (r *LocationResolver) cacheFile() string {
+	if r.CacheFile != "" {
+		return r.CacheFile
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "wwlp", "locations.json")
+}
+
+func (r *LocationResolver) loadCache() map[string]Location {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.cache != nil {
+		return r.cache
+	}
+	r.cache = map[string]Location{}
+	data, err := os.ReadFile(r.cacheFile())
+	if err == nil {
+		_ = json.Unmarshal(data, &r.cache)
+	}
+	return r.cache
+}
+
+func (r *LocationResolver) saveCache() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.Marshal(r.cache)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(r.cacheFile()), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(r.cacheFile(), data, 0o644)
+}
+
+// Resolve geocodes place (consulting and updating the on-disk cache) and
+// returns a Location with Lat/Lng, CountyFIPS, and ForecastZone filled in.
+func (r *LocationResolver) Resolve(ctx context.Context, place string) (Location, error) {
+	key := strings.ToLower(strings.TrimSpace(place))
+	cache := r.loadCache()
+	if loc, ok := cache[key]; ok {
+		return loc, nil
+	}
+
+	lat, lng, name, err := r.geocode(ctx, place)
+	if err != nil {
+		return Location{}, fmt.Errorf("geocode %q: %w", place, err)
+	}
+	countyFIPS, forecastZone, err := r.nwsZones(ctx, lat, lng)
+	if err != nil {
+		return Location{}, fmt.Errorf("resolve zones for %q: %w", place, err)
+	}
+
+	loc := Location{Lat: lat, Lng: lng, Name: name, CountyFIPS: countyFIPS, ForecastZone: forecastZone}
+	r.mu.Lock()
+	r.cache[key] = loc
+	r.mu.Unlock()
+	r.saveCache()
+	return loc, nil
+}
+
+type nominatimResult struct {
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	DisplayName string `json:"display_name"`
+}
+
+func (r *LocationResolver) geocode(ctx context.Context, place string) (lat, lng float64, name string, err error) {
+	u := "https://nominatim.openstreetmap.org/search?" + url.Values{
+		"q":      {place},
+		"format": {"json"},
+		"limit":  {"1"},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("User-Agent", r.UserAgent)
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, 0, "", fmt.Errorf("http status: %s", resp.Status)
+	}
+
+	var results []nominatimResult
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return 0, 0, "", fmt.Errorf("decode json: %w", err)
+	}
+	if len(results) == 0 {
+		return 0, 0, "", fmt.Errorf("no match for place: %s", place)
+	}
+	lat, err = strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("parse lat: %w", err)
+	}
+	lng, err = strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return 0, 0, "", fmt.Errorf("parse lon: %w", err)
+	}
+	return lat, lng, results[0].DisplayName, nil
+}
+
+type nwsPointResponse struct {
+	Properties struct {
+		County       string `json:"county"`
+		ForecastZone string `json:"forecastZone"`
+	} `json:"properties"`
+}
+
+// stateFIPSByAbbr maps USPS state (and DC/territory) abbreviations to
+// their 2-digit Census FIPS state code, the piece NWS's county UGC codes
+// leave implicit (see fipsFromCountyZoneID).
+var stateFIPSByAbbr = map[string]string{
+	"AL": "01", "AK": "02", "AZ": "04", "AR": "05", "CA": "06", "CO": "08",
+	"CT": "09", "DE": "10", "DC": "11", "FL": "12", "GA": "13", "HI": "15",
+	"ID": "16", "IL": "17", "IN": "18", "IA": "19", "KS": "20", "KY": "21",
+	"LA": "22", "ME": "23", "MD": "24", "MA": "25", "MI": "26", "MN": "27",
+	"MS": "28", "MO": "29", "MT": "30", "NE": "31", "NV": "32", "NH": "33",
+	"NJ": "34", "NM": "35", "NY": "36", "NC": "37", "ND": "38", "OH": "39",
+	"OK": "40", "OR": "41", "PA": "42", "RI": "44", "SC": "45", "SD": "46",
+	"TN": "47", "TX": "48", "UT": "49", "VT": "50", "VA": "51", "WA": "53",
+	"WV": "54", "WI": "55", "WY": "56", "PR": "72", "VI": "78", "GU": "66",
+	"AS": "60", "MP": "69",
+}
+
+// fipsFromCountyZoneID derives a 5-digit Census FIPS county code from an
+// NWS county UGC zone ID (e.g. "MAC013"). NWS's UGC format is the 2-letter
+// state abbreviation, a zone-type letter ("C" for county), and the
+// state's own 3-digit FIPS county number, so the only missing piece is
+// the 2-digit state FIPS prefix, looked up via stateFIPSByAbbr.
+func fipsFromCountyZoneID(zoneID string) (string, bool) {
+	if len(zoneID) < 4 {
+		return "", false
+	}
+	abbr := strings.ToUpper(zoneID[:2])
+	tail := zoneID[3:]
+	if _, err := strconv.Atoi(tail); err != nil {
+		return "", false
+	}
+	stateFIPS, ok := stateFIPSByAbbr[abbr]
+	if !ok {
+		return "", false
+	}
+	return stateFIPS + tail, true
+}
+
+// nwsZones resolves a lat/lng to its NWS county FIPS code and forecast
+// zone ID via /points/{lat},{lon}. The forecast zone is the trailing ID
+// of its URL (e.g. ".../zones/forecast/MAZ004" -> "MAZ004"); the county
+// FIPS code is derived from the trailing ID of the county zone URL (e.g.
+// ".../zones/county/MAC013" -> "25013") via fipsFromCountyZoneID, since
+// NWS's "county" field is itself a zone ID, not a FIPS code.
+func (r *LocationResolver) nwsZones(ctx context.Context, lat, lng float64) (countyFIPS, forecastZone string, err error) {
+	u := fmt.Sprintf("https://api.weather.gov/points/%.4f,%.4f", lat, lng)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/geo+json")
+	req.Header.Set("User-Agent", r.UserAgent)
+
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return "", "", fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", "", fmt.Errorf("http status: %s", resp.Status)
+	}
+
+	var parsed nwsPointResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return "", "", fmt.Errorf("decode json: %w", err)
+	}
+	zoneID := path.Base(parsed.Properties.County)
+	fips, ok := fipsFromCountyZoneID(zoneID)
+	if !ok {
+		return "", "", fmt.Errorf("can't derive county FIPS from zone ID: %s", zoneID)
+	}
+	return fips, path.Base(parsed.Properties.ForecastZone), nil
+}
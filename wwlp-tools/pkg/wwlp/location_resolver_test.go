@@ -0,0 +1,16 @@
+package wwlp
+
+import "testing"
+
+func TestFipsFromCountyZoneIDDerivesFIPS(t *testing.T) {
+	got, ok := fipsFromCountyZoneID("MAC013")
+	if !ok || got != "25013" {
+		t.Fatalf("fipsFromCountyZoneID(MAC013) = %q, %v, want 25013, true", got, ok)
+	}
+}
+
+func TestFipsFromCountyZoneIDRejectsUnknownState(t *testing.T) {
+	if _, ok := fipsFromCountyZoneID("ZZC013"); ok {
+		t.Fatalf("expected ok=false for an unrecognized state abbreviation")
+	}
+}
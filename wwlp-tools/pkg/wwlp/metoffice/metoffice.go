@@ -0,0 +1,219 @@
+// Package metoffice is a wwlp.WeatherProvider backend for the UK Met
+// Office's DataPoint 3-hourly forecast feed, queried by numeric location
+// ID and returning the aggregated site report JSON.
+package metoffice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+const baseURL = "https://datapoint.metoffice.gov.uk/public/data/val/wxfcs/all/json"
+
+type Client struct {
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+func NewClient(apiKey string) *Client {
+	return &Client{APIKey: apiKey, HTTPClient: &http.Client{Timeout: 15 * time.Second}}
+}
+
+type siteReportResponse struct {
+	SiteRep struct {
+		DV struct {
+			Location struct {
+				Period []period `json:"Period"`
+			} `json:"Location"`
+		} `json:"DV"`
+	} `json:"SiteRep"`
+}
+
+type period struct {
+	Value string `json:"value"`
+	Rep   []rep  `json:"Rep"`
+}
+
+type rep struct {
+	MinutesSinceMidnight string `json:"$"`
+	TemperatureC         string `json:"T"`
+	WeatherType          string `json:"W"`
+	PrecipProbability    string `json:"Pp"`
+}
+
+// weatherTypePhrases maps DataPoint's numeric "significant weather type"
+// codes to short phrases, per the Met Office's published code table.
+var weatherTypePhrases = map[string]string{
+	"0": "Clear sky", "1": "Sunny", "2": "Partly cloudy", "3": "Partly cloudy",
+	"5": "Mist", "6": "Fog", "7": "Cloudy", "8": "Overcast",
+	"9": "Light rain shower", "10": "Light rain shower", "11": "Drizzle",
+	"12": "Light rain", "13": "Heavy rain shower", "14": "Heavy rain shower",
+	"15": "Heavy rain", "16": "Sleet shower", "17": "Sleet shower", "18": "Sleet",
+	"19": "Hail shower", "20": "Hail shower", "21": "Hail",
+	"22": "Light snow shower", "23": "Light snow shower", "24": "Light snow",
+	"25": "Heavy snow shower", "26": "Heavy snow shower", "27": "Heavy snow",
+	"28": "Thunder shower", "29": "Thunder shower", "30": "Thunder",
+}
+
+func weatherPhrase(code string) string {
+	if phrase, ok := weatherTypePhrases[code]; ok {
+		return phrase
+	}
+	return ""
+}
+
+func (c *Client) fetch(ctx context.Context, locationID string) (*siteReportResponse, error) {
+	u := fmt.Sprintf("%s/%s?res=3hourly&key=%s", baseURL, locationID, c.APIKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("http status: %s", resp.Status)
+	}
+
+	var parsed siteReportResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decode json: %w", err)
+	}
+	return &parsed, nil
+}
+
+// Provider adapts a Client to wwlp.WeatherProvider. Location.Place holds
+// the DataPoint numeric location ID, since DataPoint forecasts are
+// requested by site rather than raw lat/lng. met.office DataPoint has no
+// public alerts feed, so Alerts always returns an empty slice.
+type Provider struct {
+	Client *Client
+}
+
+func NewProvider(name string, client *Client) *Provider {
+	p := &Provider{Client: client}
+	wwlp.RegisterWeatherProvider(name, p)
+	return p
+}
+
+func (p *Provider) Current(ctx context.Context, loc wwlp.Location) (*wwlp.WeatherPoint, error) {
+	reps, err := p.reps(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	if len(reps) == 0 {
+		return nil, fmt.Errorf("no forecast periods")
+	}
+	return weatherPointFromRep(reps[0]), nil
+}
+
+func (p *Provider) Hourly(ctx context.Context, loc wwlp.Location, limit int) ([]wwlp.HourlyForecast, error) {
+	reps, err := p.reps(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	if limit > 0 && limit < len(reps) {
+		reps = reps[:limit]
+	}
+	out := make([]wwlp.HourlyForecast, 0, len(reps))
+	for _, r := range reps {
+		out = append(out, hourlyForecastFromRep(r))
+	}
+	return out, nil
+}
+
+func (p *Provider) Daily(ctx context.Context, loc wwlp.Location, limit int) ([]wwlp.DailyForecast, error) {
+	report, err := p.Client.fetch(ctx, loc.Place)
+	if err != nil {
+		return nil, fmt.Errorf("fetch forecast: %w", err)
+	}
+	periods := report.SiteRep.DV.Location.Period
+	if limit > 0 && limit < len(periods) {
+		periods = periods[:limit]
+	}
+	out := make([]wwlp.DailyForecast, 0, len(periods))
+	for _, period := range periods {
+		out = append(out, dailyForecastFromPeriod(period))
+	}
+	return out, nil
+}
+
+func (p *Provider) Alerts(ctx context.Context, loc wwlp.Location) ([]wwlp.WeatherAlert, error) {
+	return nil, nil
+}
+
+func (p *Provider) reps(ctx context.Context, loc wwlp.Location) ([]rep, error) {
+	report, err := p.Client.fetch(ctx, loc.Place)
+	if err != nil {
+		return nil, fmt.Errorf("fetch forecast: %w", err)
+	}
+	var reps []rep
+	for _, period := range report.SiteRep.DV.Location.Period {
+		reps = append(reps, period.Rep...)
+	}
+	return reps, nil
+}
+
+func weatherPointFromRep(r rep) *wwlp.WeatherPoint {
+	return &wwlp.WeatherPoint{
+		Temperature:  celsiusToFahrenheit(r.TemperatureC),
+		Phrase:       weatherPhrase(r.WeatherType),
+		PrecipChance: r.PrecipProbability,
+	}
+}
+
+func hourlyForecastFromRep(r rep) wwlp.HourlyForecast {
+	return wwlp.HourlyForecast{
+		Time:         minutesToClock(r.MinutesSinceMidnight),
+		Temperature:  celsiusToFahrenheit(r.TemperatureC),
+		PrecipChance: r.PrecipProbability,
+		LongPhrase:   weatherPhrase(r.WeatherType),
+	}
+}
+
+func dailyForecastFromPeriod(period period) wwlp.DailyForecast {
+	var maxTemp, phrase, precip string
+	if len(period.Rep) > 0 {
+		maxTemp = celsiusToFahrenheit(period.Rep[0].TemperatureC)
+		phrase = weatherPhrase(period.Rep[0].WeatherType)
+		precip = period.Rep[0].PrecipProbability
+	}
+	return wwlp.DailyForecast{
+		DayOfWeek:      period.Value,
+		MaxTemperature: maxTemp,
+		ShortPhrase:    phrase,
+		PrecipChance:   precip,
+		Time:           period.Value,
+	}
+}
+
+// celsiusToFahrenheit converts DataPoint's Celsius temperature string (the
+// "T" field) to the bare Fahrenheit string wwlp.ConvertTemperature expects
+// every WeatherPoint/HourlyForecast/DailyForecast Temperature field to
+// hold, regardless of backend. s is returned unchanged if it doesn't parse
+// as a number.
+func celsiusToFahrenheit(s string) string {
+	c, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	return strconv.FormatFloat(c*9/5+32, 'f', 0, 64)
+}
+
+func minutesToClock(minutes string) string {
+	n, err := strconv.Atoi(minutes)
+	if err != nil {
+		return minutes
+	}
+	return fmt.Sprintf("%02d:%02d", n/60, n%60)
+}
@@ -0,0 +1,104 @@
+package nws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cacheEntry is the on-disk envelope written for each cached URL.
+type cacheEntry struct {
+	Body      []byte      `json:"body"`
+	Header    http.Header `json:"header"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
+
+// diskCache is a simple TTL cache keyed by request URL, so repeated calls
+// against the same point/gridpoint/alerts query don't hammer api.weather.gov.
+type diskCache struct {
+	dir        string
+	defaultTTL time.Duration
+}
+
+func newDiskCache(dir string, defaultTTL time.Duration) *diskCache {
+	return &diskCache{dir: dir, defaultTTL: defaultTTL}
+}
+
+func (c *diskCache) path(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (c *diskCache) get(url string) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.path(url))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		return nil, false
+	}
+	return entry.Body, true
+}
+
+func (c *diskCache) set(url string, body []byte, header http.Header) {
+	if c == nil {
+		return
+	}
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return
+	}
+	entry := cacheEntry{
+		Body:      body,
+		Header:    header,
+		ExpiresAt: time.Now().Add(c.ttlFor(header)),
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(c.path(url), data, 0o644)
+}
+
+// ttlFor honors Cache-Control: max-age and Expires when present, falling
+// back to the cache's default TTL otherwise.
+func (c *diskCache) ttlFor(header http.Header) time.Duration {
+	if cc := header.Get("Cache-Control"); cc != "" {
+		if ttl, ok := maxAgeFromCacheControl(cc); ok {
+			return ttl
+		}
+	}
+	if exp := header.Get("Expires"); exp != "" {
+		if t, err := http.ParseTime(exp); err == nil {
+			if ttl := time.Until(t); ttl > 0 {
+				return ttl
+			}
+		}
+	}
+	return c.defaultTTL
+}
+
+func maxAgeFromCacheControl(cc string) (time.Duration, bool) {
+	const prefix = "max-age="
+	for _, part := range strings.Split(cc, ",") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, prefix) {
+			if secs, err := strconv.Atoi(part[len(prefix):]); err == nil {
+				return time.Duration(secs) * time.Second, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,234 @@
+package nws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+const baseURL = "https://api.weather.gov"
+
+// defaultUserAgent identifies this client per NWS's API policy, which
+// requires a contact identifier on every request. Callers should set a
+// more specific one via Client.UserAgent.
+const defaultUserAgent = "wwlp-tools (https://github.com/mhlotto/vibrazioni)"
+
+// Client fetches points, gridpoint forecasts, and active alerts from
+// api.weather.gov and caches responses on disk by URL.
+type Client struct {
+	UserAgent  string
+	HTTPClient *http.Client
+	cache      *diskCache
+}
+
+// NewClient returns a Client that caches responses under the user's cache
+// directory for 10 minutes by default, honoring Cache-Control/Expires.
+func NewClient(userAgent string) *Client {
+	if userAgent == "" {
+		userAgent = defaultUserAgent
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return &Client{
+		UserAgent:  userAgent,
+		HTTPClient: &http.Client{Timeout: 15 * time.Second},
+		cache:      newDiskCache(filepath.Join(dir, "wwlp", "nws"), 10*time.Minute),
+	}
+}
+
+func (c *Client) get(ctx context.Context, u string, out any) error {
+	if body, ok := c.cache.get(u); ok {
+		return json.Unmarshal(body, out)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/geo+json")
+	req.Header.Set("User-Agent", c.UserAgent)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("http get: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http status: %s", resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode json: %w", err)
+	}
+	c.cache.set(u, body, resp.Header)
+	return nil
+}
+
+// Points resolves a lat/lon to its forecast grid and relative location via
+// GET /points/{lat},{lon}.
+func (c *Client) Points(ctx context.Context, lat, lon float64) (*Point, error) {
+	u := fmt.Sprintf("%s/points/%s,%s", baseURL, trimFloat(lat), trimFloat(lon))
+	var resp pointsResponse
+	if err := c.get(ctx, u, &resp); err != nil {
+		return nil, err
+	}
+	return &Point{
+		GridID:            resp.Properties.GridID,
+		GridX:             resp.Properties.GridX,
+		GridY:             resp.Properties.GridY,
+		City:              resp.Properties.RelativeLocation.Properties.City,
+		State:             resp.Properties.RelativeLocation.Properties.State,
+		ForecastURL:       resp.Properties.Forecast,
+		ForecastHourlyURL: resp.Properties.ForecastHourly,
+	}, nil
+}
+
+// Forecast fetches the periodic forecast for a grid via
+// GET /gridpoints/{office}/{x},{y}/forecast.
+func (c *Client) Forecast(ctx context.Context, gridID string, gridX, gridY int) (*Forecast, error) {
+	u := fmt.Sprintf("%s/gridpoints/%s/%d,%d/forecast", baseURL, gridID, gridX, gridY)
+	return c.forecastAt(ctx, u)
+}
+
+// HourlyForecast fetches the hourly forecast for a grid via
+// GET /gridpoints/{office}/{x},{y}/forecast/hourly.
+func (c *Client) HourlyForecast(ctx context.Context, gridID string, gridX, gridY int) (*Forecast, error) {
+	u := fmt.Sprintf("%s/gridpoints/%s/%d,%d/forecast/hourly", baseURL, gridID, gridX, gridY)
+	return c.forecastAt(ctx, u)
+}
+
+func (c *Client) forecastAt(ctx context.Context, u string) (*Forecast, error) {
+	var resp forecastResponse
+	if err := c.get(ctx, u, &resp); err != nil {
+		return nil, err
+	}
+	return &Forecast{Periods: resp.Properties.Periods}, nil
+}
+
+// ActiveAlerts fetches active alerts via GET /alerts/active with the given
+// query (e.g. {"zone": [...]} or {"area": [...]}) and normalizes the
+// resulting FeatureCollection into wwlp.WeatherAlert values.
+func (c *Client) ActiveAlerts(ctx context.Context, query url.Values) ([]wwlp.WeatherAlert, error) {
+	u := fmt.Sprintf("%s/alerts/active", baseURL)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	var resp alertsResponse
+	if err := c.get(ctx, u, &resp); err != nil {
+		return nil, err
+	}
+	alerts := make([]wwlp.WeatherAlert, 0, len(resp.Features))
+	for _, f := range resp.Features {
+		alerts = append(alerts, alertFromFeature(f))
+	}
+	return alerts, nil
+}
+
+func alertFromFeature(f alertFeature) wwlp.WeatherAlert {
+	p := f.Properties
+	return wwlp.WeatherAlert{
+		AlertKey:           p.ID,
+		EffectiveTimestamp: p.Effective,
+		ExpireTimestamp:    p.Expires,
+		CreateTimestamp:    p.Sent,
+		Description:        p.Headline,
+		Severity:           p.Severity,
+		Phenomena:          p.Event,
+		AreaName:           p.AreaDesc,
+		WeatherDetail: wwlp.WeatherAlertDetail{
+			AlertType:          p.Event,
+			AreaName:           p.AreaDesc,
+			LongDescription:    p.Description,
+			EffectiveTimestamp: p.Effective,
+			ExpireTimestamp:    p.Expires,
+			Payload:            alertPayloadJSON(p),
+		},
+	}
+}
+
+// alertPayloadJSON encodes the NWS properties into the same
+// WeatherAlertPayload shape ParseWeatherAlertPayload expects from the
+// Nexstar feed, so downstream consumers work identically against either
+// source.
+func alertPayloadJSON(p alertProperties) string {
+	payload := wwlp.WeatherAlertPayload{
+		AreaName:         p.AreaDesc,
+		EventDescription: p.Event,
+		HeadlineText:     p.Headline,
+		EffectiveTime:    p.Effective,
+		ExpireTime:       p.Expires,
+		Severity:         p.Severity,
+		Urgency:          p.Urgency,
+		Certainty:        p.Certainty,
+		Texts: []wwlp.WeatherAlertText{
+			{Description: p.Description, LanguageCode: "en-US"},
+		},
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// AreaForecastDiscussion fetches the latest Area Forecast Discussion (AFD)
+// text product issued by the given forecast office, mapped into
+// wwlp.ForecastDiscussion for compatibility with Nexstar-sourced discussions.
+func (c *Client) AreaForecastDiscussion(ctx context.Context, wfoID string) (*wwlp.ForecastDiscussion, error) {
+	var list productsListResponse
+	listURL := fmt.Sprintf("%s/products/types/AFD/locations/%s", baseURL, wfoID)
+	if err := c.get(ctx, listURL, &list); err != nil {
+		return nil, fmt.Errorf("list AFD products: %w", err)
+	}
+	if len(list.Products) == 0 {
+		return nil, fmt.Errorf("no AFD products for office: %s", wfoID)
+	}
+
+	var product productResponse
+	productURL := fmt.Sprintf("%s/products/%s", baseURL, list.Products[0].ID)
+	if err := c.get(ctx, productURL, &product); err != nil {
+		return nil, fmt.Errorf("fetch AFD product: %w", err)
+	}
+
+	return &wwlp.ForecastDiscussion{
+		Headline:      fmt.Sprintf("Area Forecast Discussion (%s)", wfoID),
+		DatePublished: product.IssuanceTime,
+		ArticleBody:   product.ProductText,
+		Genre:         []string{"Weather News"},
+	}, nil
+}
+
+// LoadForecastFromNWS resolves the grid for a point and returns its
+// periodic forecast in one call.
+func LoadForecastFromNWS(ctx context.Context, client *Client, lat, lon float64) (*Forecast, error) {
+	point, err := client.Points(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("resolve point: %w", err)
+	}
+	return client.Forecast(ctx, point.GridID, point.GridX, point.GridY)
+}
+
+func trimFloat(f float64) string {
+	s := fmt.Sprintf("%.4f", f)
+	for len(s) > 0 && s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if len(s) > 0 && s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
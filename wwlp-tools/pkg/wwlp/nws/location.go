@@ -0,0 +1,102 @@
+package nws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+// countyFIPSByCity is a small bundled table mapping the principal city NWS
+// reports in Point.RelativeLocation to the Nexstar-style Massachusetts
+// county FIPS code covering WWLP's market, so a resolved point can be
+// handed to the existing Nexstar alerts fetcher without the caller needing
+// to know county codes. It's deliberately narrow rather than a full
+// geocoder — see LocationResolver for free-text place lookup.
+var countyFIPSByCity = map[string]string{
+	"springfield": "25013", // Hampden
+	"northampton": "25015", // Hampshire
+	"pittsfield":  "25003", // Berkshire
+	"greenfield":  "25011", // Franklin
+}
+
+// placeCoordinates is a small bundled table of lat/lon for named places in
+// WWLP's market, used by LoadWeatherAlertsByPlace until a full geocoder is
+// wired in.
+var placeCoordinates = map[string][2]float64{
+	"springfield, ma": {42.1015, -72.5898},
+	"northampton, ma": {42.3251, -72.6412},
+	"pittsfield, ma":  {42.4501, -73.2454},
+	"greenfield, ma":  {42.5876, -72.5995},
+}
+
+// LoadWeatherAlertsByPoint resolves lat/lon to its covering county via
+// NWS /points and delegates to the existing Nexstar county-based fetcher.
+func LoadWeatherAlertsByPoint(ctx context.Context, client *Client, lat, lon float64) ([]wwlp.WeatherAlert, error) {
+	point, err := client.Points(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("resolve point: %w", err)
+	}
+	fips, ok := countyFIPSByCity[strings.ToLower(point.City)]
+	if !ok {
+		return nil, fmt.Errorf("no county mapping for city: %s", point.City)
+	}
+	return wwlp.LoadWeatherAlertsURL(fips)
+}
+
+// LoadWeatherAlertsByPlace resolves a bundled place name (e.g.
+// "Springfield, MA") to coordinates and delegates to
+// LoadWeatherAlertsByPoint.
+func LoadWeatherAlertsByPlace(ctx context.Context, client *Client, name string) ([]wwlp.WeatherAlert, error) {
+	coords, ok := placeCoordinates[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("unknown place: %s", name)
+	}
+	return LoadWeatherAlertsByPoint(ctx, client, coords[0], coords[1])
+}
+
+// ForecastBundle aggregates everything LoadForecastBundle fetches for a
+// single point, so callers get periodic and hourly forecasts plus active
+// alerts in one call.
+type ForecastBundle struct {
+	Point          *Point
+	Forecast       *Forecast
+	ForecastHourly *Forecast
+	Alerts         []wwlp.WeatherAlert
+}
+
+// LoadForecastBundle resolves lat/lon and fetches its periodic forecast,
+// hourly forecast, and active alerts.
+func LoadForecastBundle(ctx context.Context, client *Client, lat, lon float64) (*ForecastBundle, error) {
+	point, err := client.Points(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("resolve point: %w", err)
+	}
+	forecast, err := client.Forecast(ctx, point.GridID, point.GridX, point.GridY)
+	if err != nil {
+		return nil, fmt.Errorf("fetch forecast: %w", err)
+	}
+	hourly, err := client.HourlyForecast(ctx, point.GridID, point.GridX, point.GridY)
+	if err != nil {
+		return nil, fmt.Errorf("fetch hourly forecast: %w", err)
+	}
+	alerts, err := client.ActiveAlerts(ctx, url.Values{"point": {fmt.Sprintf("%s,%s", trimFloat(lat), trimFloat(lon))}})
+	if err != nil {
+		return nil, fmt.Errorf("fetch alerts: %w", err)
+	}
+	return &ForecastBundle{Point: point, Forecast: forecast, ForecastHourly: hourly, Alerts: alerts}, nil
+}
+
+// LoadForecastDiscussionByPoint resolves lat/lon to its forecast office and
+// fetches that office's latest Area Forecast Discussion text product,
+// mapped into wwlp.ForecastDiscussion so it's a drop-in for the
+// Nexstar-sourced forecast discussion.
+func LoadForecastDiscussionByPoint(ctx context.Context, client *Client, lat, lon float64) (*wwlp.ForecastDiscussion, error) {
+	point, err := client.Points(ctx, lat, lon)
+	if err != nil {
+		return nil, fmt.Errorf("resolve point: %w", err)
+	}
+	return client.AreaForecastDiscussion(ctx, point.GridID)
+}
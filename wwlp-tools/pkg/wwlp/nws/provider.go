@@ -0,0 +1,133 @@
+package nws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+// Provider adapts a Client to wwlp.WeatherProvider, mapping NWS gridpoint
+// forecast periods into the module's WeatherPoint/HourlyForecast/
+// DailyForecast shapes.
+type Provider struct {
+	Client *Client
+}
+
+// NewProvider returns a Provider backed by client and registers it with
+// the wwlp package under the given name (typically "nws").
+func NewProvider(name string, client *Client) *Provider {
+	p := &Provider{Client: client}
+	wwlp.RegisterWeatherProvider(name, p)
+	return p
+}
+
+func (p *Provider) point(ctx context.Context, loc wwlp.Location) (*Point, error) {
+	point, err := p.Client.Points(ctx, loc.Lat, loc.Lng)
+	if err != nil {
+		return nil, fmt.Errorf("resolve point: %w", err)
+	}
+	return point, nil
+}
+
+func (p *Provider) Current(ctx context.Context, loc wwlp.Location) (*wwlp.WeatherPoint, error) {
+	point, err := p.point(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	forecast, err := p.Client.Forecast(ctx, point.GridID, point.GridX, point.GridY)
+	if err != nil {
+		return nil, fmt.Errorf("fetch forecast: %w", err)
+	}
+	if len(forecast.Periods) == 0 {
+		return nil, fmt.Errorf("no forecast periods")
+	}
+	return weatherPointFromPeriod(forecast.Periods[0]), nil
+}
+
+func (p *Provider) Hourly(ctx context.Context, loc wwlp.Location, limit int) ([]wwlp.HourlyForecast, error) {
+	point, err := p.point(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	forecast, err := p.Client.HourlyForecast(ctx, point.GridID, point.GridX, point.GridY)
+	if err != nil {
+		return nil, fmt.Errorf("fetch hourly forecast: %w", err)
+	}
+	periods := forecast.Periods
+	if limit > 0 && limit < len(periods) {
+		periods = periods[:limit]
+	}
+	out := make([]wwlp.HourlyForecast, 0, len(periods))
+	for _, period := range periods {
+		out = append(out, hourlyForecastFromPeriod(period))
+	}
+	return out, nil
+}
+
+// Daily pairs consecutive day/night periods from the 12-hour gridpoint
+// forecast into one DailyForecast per day, the way NWS's own forecast page
+// presents them.
+func (p *Provider) Daily(ctx context.Context, loc wwlp.Location, limit int) ([]wwlp.DailyForecast, error) {
+	point, err := p.point(ctx, loc)
+	if err != nil {
+		return nil, err
+	}
+	forecast, err := p.Client.Forecast(ctx, point.GridID, point.GridX, point.GridY)
+	if err != nil {
+		return nil, fmt.Errorf("fetch forecast: %w", err)
+	}
+
+	var out []wwlp.DailyForecast
+	for i := 0; i < len(forecast.Periods); i++ {
+		day := forecast.Periods[i]
+		if !day.IsDaytime {
+			continue
+		}
+		daily := wwlp.DailyForecast{
+			DayOfWeek:      day.Name,
+			MaxTemperature: strconv.Itoa(day.Temperature),
+			ShortPhrase:    day.ShortForecast,
+			DayNarrative:   day.DetailedForecast,
+			Time:           day.StartTime,
+		}
+		if i+1 < len(forecast.Periods) && !forecast.Periods[i+1].IsDaytime {
+			night := forecast.Periods[i+1]
+			daily.MinTemperature = strconv.Itoa(night.Temperature)
+			daily.NightNarrative = night.DetailedForecast
+			i++
+		}
+		out = append(out, daily)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (p *Provider) Alerts(ctx context.Context, loc wwlp.Location) ([]wwlp.WeatherAlert, error) {
+	query := url.Values{}
+	if loc.Place != "" {
+		query.Set("zone", loc.Place)
+	} else {
+		query.Set("point", fmt.Sprintf("%s,%s", trimFloat(loc.Lat), trimFloat(loc.Lng)))
+	}
+	return p.Client.ActiveAlerts(ctx, query)
+}
+
+func weatherPointFromPeriod(period ForecastPeriod) *wwlp.WeatherPoint {
+	return &wwlp.WeatherPoint{
+		Temperature: strconv.Itoa(period.Temperature),
+		Phrase:      period.ShortForecast,
+	}
+}
+
+func hourlyForecastFromPeriod(period ForecastPeriod) wwlp.HourlyForecast {
+	return wwlp.HourlyForecast{
+		Time:        period.StartTime,
+		Temperature: strconv.Itoa(period.Temperature),
+		LongPhrase:  period.DetailedForecast,
+	}
+}
@@ -0,0 +1,39 @@
+package nws
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"wwlp-tools/pkg/wwlp"
+)
+
+// Source adapts a Client to wwlp.Source so it can be registered alongside
+// the Nexstar backend and selected at runtime.
+type Source struct {
+	Client *Client
+}
+
+// NewSource returns a Source backed by client and registers it with the
+// wwlp package under the given name (typically "nws").
+func NewSource(name string, client *Client) *Source {
+	s := &Source{Client: client}
+	wwlp.RegisterSource(name, s)
+	return s
+}
+
+// Fetch resolves q.Counties as a comma-separated list of NWS zone/area IDs
+// and fetches active alerts for them. The returned ForecastDiscussion is
+// always nil: Fetch does not request an Area Forecast Discussion (see
+// Client.AreaForecastDiscussion for that).
+func (s *Source) Fetch(ctx context.Context, q wwlp.Query) ([]wwlp.WeatherAlert, *wwlp.ForecastDiscussion, error) {
+	query := url.Values{}
+	if q.Counties != "" {
+		query.Set("zone", q.Counties)
+	}
+	alerts, err := s.Client.ActiveAlerts(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch nws alerts: %w", err)
+	}
+	return alerts, nil, nil
+}
@@ -0,0 +1,89 @@
+// Package nws fetches alerts and forecasts directly from api.weather.gov
+// and normalizes them into the wwlp package's WeatherAlert/ForecastDiscussion
+// shapes, so callers aren't tied to the Nexstar/PSG endpoint.
+package nws
+
+type Point struct {
+	GridID            string
+	GridX             int
+	GridY             int
+	City              string
+	State             string
+	ForecastURL       string
+	ForecastHourlyURL string
+}
+
+type pointsResponse struct {
+	Properties struct {
+		GridID           string `json:"gridId"`
+		GridX            int    `json:"gridX"`
+		GridY            int    `json:"gridY"`
+		Forecast         string `json:"forecast"`
+		ForecastHourly   string `json:"forecastHourly"`
+		RelativeLocation struct {
+			Properties struct {
+				City  string `json:"city"`
+				State string `json:"state"`
+			} `json:"properties"`
+		} `json:"relativeLocation"`
+	} `json:"properties"`
+}
+
+type Forecast struct {
+	Periods []ForecastPeriod
+}
+
+type ForecastPeriod struct {
+	Number           int    `json:"number"`
+	Name             string `json:"name"`
+	StartTime        string `json:"startTime"`
+	EndTime          string `json:"endTime"`
+	IsDaytime        bool   `json:"isDaytime"`
+	Temperature      int    `json:"temperature"`
+	TemperatureUnit  string `json:"temperatureUnit"`
+	WindSpeed        string `json:"windSpeed"`
+	WindDirection    string `json:"windDirection"`
+	ShortForecast    string `json:"shortForecast"`
+	DetailedForecast string `json:"detailedForecast"`
+}
+
+type forecastResponse struct {
+	Properties struct {
+		Periods []ForecastPeriod `json:"periods"`
+	} `json:"properties"`
+}
+
+type alertsResponse struct {
+	Features []alertFeature `json:"features"`
+}
+
+type alertFeature struct {
+	Properties alertProperties `json:"properties"`
+}
+
+type productsListResponse struct {
+	Products []struct {
+		ID string `json:"id"`
+	} `json:"@graph"`
+}
+
+type productResponse struct {
+	ID           string `json:"id"`
+	IssuanceTime string `json:"issuanceTime"`
+	ProductText  string `json:"productText"`
+}
+
+type alertProperties struct {
+	ID          string `json:"id"`
+	AreaDesc    string `json:"areaDesc"`
+	Event       string `json:"event"`
+	Severity    string `json:"severity"`
+	Certainty   string `json:"certainty"`
+	Urgency     string `json:"urgency"`
+	Headline    string `json:"headline"`
+	Description string `json:"description"`
+	Instruction string `json:"instruction"`
+	Effective   string `json:"effective"`
+	Expires     string `json:"expires"`
+	Sent        string `json:"sent"`
+}
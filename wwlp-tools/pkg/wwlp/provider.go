@@ -0,0 +1,145 @@
+package wwlp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Location is where a WeatherProvider should fetch data for: either a
+// lat/lng pair or a provider-specific place identifier (a WWLP county
+// list, an NWS zone, a Met Office DataPoint location ID, ...). CountyFIPS,
+// ForecastZone, and Name are filled in by LocationResolver when a Location
+// comes from a free-text place lookup rather than being built by hand.
+type Location struct {
+	Lat          float64
+	Lng          float64
+	Place        string
+	Name         string
+	CountyFIPS   string
+	ForecastZone string
+}
+
+// WeatherProvider fetches current, hourly, and daily weather plus alerts
+// for a Location, normalized into the module's shared WeatherPoint/
+// HourlyForecast/DailyForecast/WeatherAlert types so callers (and the CLI's
+// --provider flag) can swap backends without touching downstream code.
+type WeatherProvider interface {
+	Current(ctx context.Context, loc Location) (*WeatherPoint, error)
+	Hourly(ctx context.Context, loc Location, limit int) ([]HourlyForecast, error)
+	Daily(ctx context.Context, loc Location, limit int) ([]DailyForecast, error)
+	Alerts(ctx context.Context, loc Location) ([]WeatherAlert, error)
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]WeatherProvider{
+		"wwlp": WWLPProvider{},
+	}
+)
+
+// RegisterWeatherProvider makes a WeatherProvider available under name for
+// later lookup with GetWeatherProvider. Backend packages (e.g. nws,
+// metoffice) call this from their own constructors rather than wwlp
+// importing them directly, which would create an import cycle.
+func RegisterWeatherProvider(name string, p WeatherProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = p
+}
+
+// GetWeatherProvider looks up a previously registered WeatherProvider by
+// name.
+func GetWeatherProvider(name string) (WeatherProvider, bool) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[name]
+	return p, ok
+}
+
+// WeatherProviderNames returns the names of all registered providers,
+// sorted.
+func WeatherProviderNames() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	out := make([]string, 0, len(providers))
+	for name := range providers {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// WWLPProvider is the existing WWLP WordPress template-variables backend,
+// exposed as a WeatherProvider so it can be selected alongside nws or
+// metoffice via --provider. Current, Hourly, and Daily ignore Location:
+// WWLP's endpoint always describes its own western-Massachusetts coverage
+// area. Alerts uses Location.Place as the county list when it is set, and
+// Counties otherwise.
+type WWLPProvider struct {
+	TemplateVarsURL string
+	Counties        string
+}
+
+func (p WWLPProvider) templateVarsURL() string {
+	if p.TemplateVarsURL != "" {
+		return p.TemplateVarsURL
+	}
+	return "https://www.wwlp.com/wp-json/lakana/v1/template-variables/"
+}
+
+func (p WWLPProvider) Current(ctx context.Context, loc Location) (*WeatherPoint, error) {
+	tv, _, err := LoadTemplateVarsURLContext(ctx, p.templateVarsURL(), ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("fetch current: %w", err)
+	}
+	if tv.Weather == nil || tv.Weather.ThreeDay == nil || tv.Weather.ThreeDay.Current == nil {
+		return nil, fmt.Errorf("current weather missing")
+	}
+	return tv.Weather.ThreeDay.Current, nil
+}
+
+func (p WWLPProvider) Hourly(ctx context.Context, loc Location, limit int) ([]HourlyForecast, error) {
+	tv, _, err := LoadTemplateVarsURLContext(ctx, p.templateVarsURL(), ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("fetch hourly: %w", err)
+	}
+	if tv.Weather == nil {
+		return nil, fmt.Errorf("weather missing")
+	}
+	return limitHourly(tv.Weather.Hourly, limit), nil
+}
+
+func (p WWLPProvider) Daily(ctx context.Context, loc Location, limit int) ([]DailyForecast, error) {
+	tv, _, err := LoadTemplateVarsURLContext(ctx, p.templateVarsURL(), ClientOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("fetch daily: %w", err)
+	}
+	if tv.Weather == nil {
+		return nil, fmt.Errorf("weather missing")
+	}
+	return limitDaily(tv.Weather.SevenDay, limit), nil
+}
+
+func (p WWLPProvider) Alerts(ctx context.Context, loc Location) ([]WeatherAlert, error) {
+	counties := p.Counties
+	if loc.Place != "" {
+		counties = loc.Place
+	}
+	return LoadWeatherAlertsURLContext(ctx, counties, ClientOptions{})
+}
+
+func limitHourly(items []HourlyForecast, limit int) []HourlyForecast {
+	if limit <= 0 || limit >= len(items) {
+		return items
+	}
+	return items[:limit]
+}
+
+func limitDaily(items []DailyForecast, limit int) []DailyForecast {
+	if limit <= 0 || limit >= len(items) {
+		return items
+	}
+	return items[:limit]
+}
@@ -0,0 +1,224 @@
+package wwlp
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+//go:embed templatevars.schema.json
+var embeddedSchemaFS embed.FS
+
+// ValidationIssue is one structured finding from validating JSON against a
+// schema: where it occurred (a path such as
+// "$.alert_banners.messages.25013[0].severity", with "$" for the document
+// root; not an RFC 6901 JSON pointer), which schema
+// keyword it violates, a human message, and a severity.
+type ValidationIssue struct {
+	Path     string
+	Keyword  string
+	Message  string
+	Severity string
+}
+
+// schema is the subset of JSON Schema this package understands: type,
+// required, properties, items, additionalProperties, enum, format, and
+// $ref into the document's own $defs. The type names it matches are
+// object, array, string, number, and bool; JSON Schema's "boolean",
+// "integer", and "null" are not recognized. It's intentionally small — just
+// enough to declaratively describe the template-variables shape without
+// pulling in a full JSON-Schema implementation.
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Required             []string           `json:"required"`
+	Properties           map[string]*schema `json:"properties"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Enum                 []string           `json:"enum"`
+	Format               string             `json:"format"`
+	Defs                 map[string]*schema `json:"$defs"`
+}
+
+// ValidateTemplateVarsAgainstSchema validates data against schemaDoc (the
+// embedded templatevars.schema.json when schemaDoc is nil), returning
+// structured issues instead of the plain warning strings
+// ValidateTemplateVarsShape produces. This lets downstream projects extend
+// or override the schema without patching Go code.
+func ValidateTemplateVarsAgainstSchema(data []byte, schemaDoc io.Reader) ([]ValidationIssue, error) {
+	root, err := loadSchema(schemaDoc)
+	if err != nil {
+		return nil, fmt.Errorf("load schema: %w", err)
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return []ValidationIssue{{
+			Path:     "$",
+			Keyword:  "parse",
+			Message:  fmt.Sprintf("unable to inspect JSON shape: %v", err),
+			Severity: "error",
+		}}, nil
+	}
+
+	v := &validator{root: root}
+	return v.validate(value, root, "$"), nil
+}
+
+func loadSchema(r io.Reader) (*schema, error) {
+	var data []byte
+	var err error
+	if r == nil {
+		data, err = embeddedSchemaFS.ReadFile("templatevars.schema.json")
+	} else {
+		data, err = io.ReadAll(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+type validator struct {
+	root *schema
+}
+
+func (v *validator) resolve(s *schema) *schema {
+	if s == nil || s.Ref == "" {
+		return s
+	}
+	name := strings.TrimPrefix(s.Ref, "#/$defs/")
+	if resolved, ok := v.root.Defs[name]; ok {
+		return resolved
+	}
+	return s
+}
+
+func (v *validator) validate(value any, s *schema, path string) []ValidationIssue {
+	s = v.resolve(s)
+	if s == nil {
+		return nil
+	}
+
+	var issues []ValidationIssue
+	if value == nil {
+		if s.Type != "" {
+			issues = append(issues, ValidationIssue{Path: path, Keyword: "type", Message: fmt.Sprintf("key is null: %s", leafKey(path)), Severity: "warning"})
+		}
+		return issues
+	}
+
+	if s.Type != "" {
+		if got := jsonTypeOf(value); got != s.Type {
+			issues = append(issues, ValidationIssue{
+				Path:     path,
+				Keyword:  "type",
+				Message:  fmt.Sprintf("unexpected type for %s: %s", leafKey(path), got),
+				Severity: "warning",
+			})
+			return issues
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		if str, ok := value.(string); !ok || !containsString(s.Enum, str) {
+			issues = append(issues, ValidationIssue{
+				Path:     path,
+				Keyword:  "enum",
+				Message:  fmt.Sprintf("%s: value %v not in %v", leafKey(path), value, s.Enum),
+				Severity: "warning",
+			})
+		}
+	}
+
+	if s.Format == "date-time" {
+		if str, ok := value.(string); ok && str != "" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				issues = append(issues, ValidationIssue{
+					Path:     path,
+					Keyword:  "format",
+					Message:  fmt.Sprintf("%s: not a valid RFC3339 timestamp: %q", leafKey(path), str),
+					Severity: "warning",
+				})
+			}
+		}
+	}
+
+	obj, isObject := value.(map[string]any)
+	if isObject {
+		for _, key := range s.Required {
+			if _, ok := obj[key]; !ok {
+				issues = append(issues, ValidationIssue{
+					Path:     path + "." + key,
+					Keyword:  "required",
+					Message:  fmt.Sprintf("missing key: %s", key),
+					Severity: "warning",
+				})
+			}
+		}
+		for key, propSchema := range s.Properties {
+			child, ok := obj[key]
+			if !ok {
+				continue
+			}
+			issues = append(issues, v.validate(child, propSchema, path+"."+key)...)
+		}
+		if s.AdditionalProperties != nil {
+			for key, child := range obj {
+				if _, declared := s.Properties[key]; declared {
+					continue
+				}
+				issues = append(issues, v.validate(child, s.AdditionalProperties, path+"."+key)...)
+			}
+		}
+	}
+
+	if arr, isArray := value.([]any); isArray && s.Items != nil {
+		for i, item := range arr {
+			issues = append(issues, v.validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+
+	return issues
+}
+
+func leafKey(path string) string {
+	path = strings.TrimPrefix(path, "$.")
+	if i := strings.LastIndexByte(path, '.'); i >= 0 {
+		return path[i+1:]
+	}
+	return path
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonTypeOf(v any) string {
+	switch v.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case float64:
+		return "number"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,66 @@
+package wwlp
+
+import "testing"
+
+func TestValidateTemplateVarsAgainstSchemaAcceptsValidDocument(t *testing.T) {
+	input := []byte(`{
+  "top_stories": {"articles": []},
+  "additional_top_stories": {"articles": []},
+  "headline_lists": [],
+  "weather": {},
+  "alert_banners": {"messages": {}}
+}`)
+	issues, err := ValidateTemplateVarsAgainstSchema(input, nil)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("expected no issues, got %v", issues)
+	}
+}
+
+func TestValidateTemplateVarsAgainstSchemaBadSeverity(t *testing.T) {
+	input := []byte(`{
+  "top_stories": {"articles": []},
+  "additional_top_stories": {"articles": []},
+  "headline_lists": [],
+  "weather": {},
+  "alert_banners": {"messages": {"25013": [{"content": "text", "severity": "Catastrophic"}]}}
+}`)
+	issues, err := ValidateTemplateVarsAgainstSchema(input, nil)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	found := false
+	for _, issue := range issues {
+		if issue.Keyword == "enum" && issue.Path == "$.alert_banners.messages.25013[0].severity" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected an enum issue for severity, got %v", issues)
+	}
+}
+
+func TestValidateTemplateVarsAgainstSchemaArticleMissingLink(t *testing.T) {
+	input := []byte(`{
+  "top_stories": {"articles": [{"title": "headline"}]},
+  "additional_top_stories": {},
+  "headline_lists": [],
+  "weather": {},
+  "alert_banners": {}
+}`)
+	issues, err := ValidateTemplateVarsAgainstSchema(input, nil)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	found := false
+	for _, issue := range issues {
+		if issue.Message == "missing key: link" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected missing key: link, got %v", issues)
+	}
+}
@@ -0,0 +1,153 @@
+package wwlp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Query describes what a Source should fetch: a county list (Nexstar-style),
+// a lat/lon point, or a free-text place, depending on what the backend
+// supports.
+type Query struct {
+	Counties string
+	Lat      float64
+	Lon      float64
+	Place    string
+}
+
+// Source fetches weather alerts and a forecast discussion from a single
+// upstream and normalizes them into the module's shared types, so callers
+// and ValidateTemplateVarsShape-style consumers work identically regardless
+// of backend.
+type Source interface {
+	Fetch(ctx context.Context, q Query) ([]WeatherAlert, *ForecastDiscussion, error)
+}
+
+var (
+	sourcesMu sync.RWMutex
+	sources   = map[string]Source{
+		"nexstar": NexstarSource{},
+	}
+)
+
+// RegisterSource makes a Source available under name for later lookup with
+// GetSource. Backend packages (e.g. nws, metno) call this from their own
+// constructors rather than wwlp importing them directly, which would create
+// an import cycle since they depend on wwlp's types.
+func RegisterSource(name string, s Source) {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+	sources[name] = s
+}
+
+// GetSource looks up a previously registered Source by name.
+func GetSource(name string) (Source, bool) {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	s, ok := sources[name]
+	return s, ok
+}
+
+// SourceNames returns the names of all registered sources, sorted.
+func SourceNames() []string {
+	sourcesMu.RLock()
+	defer sourcesMu.RUnlock()
+	out := make([]string, 0, len(sources))
+	for name := range sources {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+const defaultForecastDiscussionURL = "https://www.wwlp.com/weather/todays-forecast/forecast-discussion/"
+
+// NexstarSource is the existing WWLP/Nexstar backend, exposed as a Source so
+// it can be swapped for nws, metno, or openweathermap at runtime.
+type NexstarSource struct {
+	Counties              string
+	ForecastDiscussionURL string
+}
+
+func (s NexstarSource) Fetch(ctx context.Context, q Query) ([]WeatherAlert, *ForecastDiscussion, error) {
+	counties := firstNonEmptyString(q.Counties, s.Counties)
+	alerts, err := LoadWeatherAlertsURLContext(ctx, counties, ClientOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch nexstar alerts: %w", err)
+	}
+
+	discussionURL := firstNonEmptyString(s.ForecastDiscussionURL, defaultForecastDiscussionURL)
+	discussion, err := LoadForecastDiscussionURLContext(ctx, discussionURL, ClientOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch nexstar forecast discussion: %w", err)
+	}
+	return alerts, discussion, nil
+}
+
+func firstNonEmptyString(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// MultiSource fans a Query out to several Sources concurrently and merges
+// the results, so one degraded upstream doesn't take down alert coverage.
+// Alerts are deduplicated by AlertKey+EffectiveTimestamp; the forecast
+// discussion is taken from the first Source, in Sources order, that
+// returns a non-nil one.
+type MultiSource struct {
+	Sources []Source
+}
+
+func (m MultiSource) Fetch(ctx context.Context, q Query) ([]WeatherAlert, *ForecastDiscussion, error) {
+	type result struct {
+		alerts     []WeatherAlert
+		discussion *ForecastDiscussion
+		err        error
+	}
+	results := make([]result, len(m.Sources))
+
+	var wg sync.WaitGroup
+	for i, src := range m.Sources {
+		wg.Add(1)
+		go func(i int, src Source) {
+			defer wg.Done()
+			alerts, discussion, err := src.Fetch(ctx, q)
+			results[i] = result{alerts: alerts, discussion: discussion, err: err}
+		}(i, src)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	var merged []WeatherAlert
+	var discussion *ForecastDiscussion
+	var firstErr error
+	for _, r := range results {
+		if r.err != nil {
+			if firstErr == nil {
+				firstErr = r.err
+			}
+			continue
+		}
+		for _, a := range r.alerts {
+			key := a.AlertKey + "|" + a.EffectiveTimestamp
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, a)
+		}
+		if discussion == nil && r.discussion != nil {
+			discussion = r.discussion
+		}
+	}
+	if len(merged) == 0 && discussion == nil && firstErr != nil {
+		return nil, nil, firstErr
+	}
+	return merged, discussion, nil
+}
@@ -0,0 +1,186 @@
+package wwlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// AlertEventType distinguishes how an alert changed between polls.
+type AlertEventType string
+
+const (
+	AlertAdded   AlertEventType = "added"
+	AlertUpdated AlertEventType = "updated"
+	AlertExpired AlertEventType = "expired"
+)
+
+// AlertEvent is a single change detected by SubscribeWeatherAlerts.
+type AlertEvent struct {
+	Type  AlertEventType `json:"type"`
+	Alert WeatherAlert   `json:"alert"`
+}
+
+// SubscribeOptions configures SubscribeWeatherAlerts.
+type SubscribeOptions struct {
+	// Interval between polls. Defaults to 60s.
+	Interval time.Duration
+	// ClientOptions is passed through to each underlying fetch.
+	ClientOptions ClientOptions
+}
+
+// SubscribeWeatherAlerts periodically polls weather alerts for counties and
+// emits AlertAdded/AlertUpdated/AlertExpired events as the active set
+// changes, keyed by AlertKey. It uses ETag/Last-Modified conditional
+// requests when the upstream supports them to avoid re-parsing an
+// unchanged response. Both channels are closed when ctx is canceled.
+func SubscribeWeatherAlerts(ctx context.Context, counties string, opts SubscribeOptions) (<-chan AlertEvent, <-chan error) {
+	events := make(chan AlertEvent)
+	errs := make(chan error, 1)
+
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 60 * time.Second
+	}
+
+	go func() {
+		defer close(events)
+		defer close(errs)
+
+		seen := make(map[string]WeatherAlert)
+		var etag, lastModified string
+
+		poll := func() bool {
+			data, newETag, newLastModified, notModified, err := fetchWeatherAlertsConditional(ctx, counties, opts.ClientOptions, etag, lastModified)
+			if err != nil {
+				select {
+				case errs <- err:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			etag, lastModified = newETag, newLastModified
+			if notModified {
+				return true
+			}
+			alerts, err := LoadWeatherAlerts(bytes.NewReader(data))
+			if err != nil {
+				select {
+				case errs <- err:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			}
+			return emitAlertEvents(ctx, events, seen, alerts)
+		}
+
+		if !poll() {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if !poll() {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, errs
+}
+
+// emitAlertEvents diffs alerts against seen (mutating it in place) and
+// sends the resulting events, returning false if ctx was canceled first.
+func emitAlertEvents(ctx context.Context, events chan<- AlertEvent, seen map[string]WeatherAlert, alerts []WeatherAlert) bool {
+	current := make(map[string]bool, len(alerts))
+	for _, alert := range alerts {
+		current[alert.AlertKey] = true
+		prev, existed := seen[alert.AlertKey]
+		var evt AlertEvent
+		switch {
+		case !existed:
+			evt = AlertEvent{Type: AlertAdded, Alert: alert}
+		case prev.EffectiveTimestamp != alert.EffectiveTimestamp || prev.ExpireTimestamp != alert.ExpireTimestamp || prev.Description != alert.Description:
+			evt = AlertEvent{Type: AlertUpdated, Alert: alert}
+		default:
+			seen[alert.AlertKey] = alert
+			continue
+		}
+		seen[alert.AlertKey] = alert
+		select {
+		case events <- evt:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for key, alert := range seen {
+		if current[key] {
+			continue
+		}
+		delete(seen, key)
+		select {
+		case events <- AlertEvent{Type: AlertExpired, Alert: alert}:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// WeatherAlertsSSEHandler streams SubscribeWeatherAlerts events as
+// text/event-stream, one JSON-encoded AlertEvent per "data:" line, for
+// browser dashboards and ticker displays.
+func WeatherAlertsSSEHandler(counties string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		events, errs := SubscribeWeatherAlerts(r.Context(), counties, SubscribeOptions{})
+		for {
+			select {
+			case evt, ok := <-events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(evt)
+				if err != nil {
+					continue
+				}
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evt.Type, data)
+				flusher.Flush()
+			case err, ok := <-errs:
+				if !ok {
+					continue
+				}
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", jsonString(err.Error()))
+				flusher.Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
+
+func jsonString(s string) string {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return `""`
+	}
+	return string(data)
+}
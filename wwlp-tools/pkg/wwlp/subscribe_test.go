@@ -0,0 +1,46 @@
+package wwlp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestEmitAlertEventsAddedUpdatedExpired(t *testing.T) {
+	seen := map[string]WeatherAlert{
+		"stale": {AlertKey: "stale", EffectiveTimestamp: "t0"},
+		"same":  {AlertKey: "same", EffectiveTimestamp: "t0"},
+	}
+	events := make(chan AlertEvent, 10)
+	ok := emitAlertEvents(context.Background(), events, seen, []WeatherAlert{
+		{AlertKey: "new", EffectiveTimestamp: "t1"},
+		{AlertKey: "same", EffectiveTimestamp: "t0"},
+	})
+	close(events)
+	if !ok {
+		t.Fatalf("expected emitAlertEvents to succeed")
+	}
+
+	var added, expired bool
+	for evt := range events {
+		switch {
+		case evt.Type == AlertAdded && evt.Alert.AlertKey == "new":
+			added = true
+		case evt.Type == AlertExpired && evt.Alert.AlertKey == "stale":
+			expired = true
+		case evt.Alert.AlertKey == "same":
+			t.Fatalf("expected no event for unchanged alert, got %v", evt)
+		}
+	}
+	if !added || !expired {
+		t.Fatalf("expected both added and expired events, added=%v expired=%v", added, expired)
+	}
+}
+
+func TestWebsocketAcceptKnownVector(t *testing.T) {
+	// RFC 6455 section 1.3 example.
+	got := websocketAccept("dGhlIHNhbXBsZSBub25jZQ==")
+	want := "s3pPLMBiTxaQ9kYGzzhZRbK+xOo="
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
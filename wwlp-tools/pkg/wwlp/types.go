@@ -81,12 +81,14 @@ type HourlyForecast struct {
 }
 
 type DailyForecast struct {
-	DayOfWeek     string `json:"day_of_week"`
+	DayOfWeek      string `json:"day_of_week"`
 	MaxTemperature string `json:"max_temperature"`
 	MinTemperature string `json:"min_temperature"`
-	PrecipChance  string `json:"precip_chance"`
-	ShortPhrase   string `json:"short_phrase"`
-	Time          string `json:"time"`
+	PrecipChance   string `json:"precip_chance"`
+	ShortPhrase    string `json:"short_phrase"`
+	DayNarrative   string `json:"day_narrative"`
+	NightNarrative string `json:"night_narrative"`
+	Time           string `json:"time"`
 }
 
 type AlertBanners struct {
@@ -0,0 +1,89 @@
+package wwlp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Units selects the measurement system weather output is rendered in.
+// Imperial is WWLP's native Fahrenheit/mph; Metric and SI both render
+// Celsius, differing only in wind speed (km/h vs the strict SI m/s).
+type Units string
+
+const (
+	Imperial Units = "imperial"
+	Metric   Units = "metric"
+	SI       Units = "si"
+)
+
+// ParseUnits validates a --units flag value, case-insensitively.
+func ParseUnits(s string) (Units, error) {
+	switch Units(strings.ToLower(strings.TrimSpace(s))) {
+	case Imperial, Metric, SI:
+		return Units(strings.ToLower(strings.TrimSpace(s))), nil
+	default:
+		return "", fmt.Errorf("unknown units: %s (want imperial, metric, or si)", s)
+	}
+}
+
+// TemperatureSuffix returns the single-letter unit WWLP/NWS/Met Office
+// temperatures should be labeled with under u.
+func TemperatureSuffix(u Units) string {
+	if u == Metric || u == SI {
+		return "C"
+	}
+	return "F"
+}
+
+// ConvertTemperature parses s (a bare Fahrenheit number, the format every
+// WeatherPoint/HourlyForecast/DailyForecast temperature field is stored
+// in, regardless of backend) and returns it formatted for u, without a
+// unit suffix. s is returned unchanged if it doesn't parse as a number.
+func ConvertTemperature(s string, u Units) string {
+	f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return s
+	}
+	if u == Metric || u == SI {
+		f = (f - 32) * 5 / 9
+	}
+	return strconv.FormatFloat(f, 'f', 0, 64)
+}
+
+// WindSpeedSuffix returns the unit wind speeds should be labeled with
+// under u: mph for Imperial, km/h for Metric, and the strict SI m/s.
+func WindSpeedSuffix(u Units) string {
+	switch u {
+	case SI:
+		return "m/s"
+	case Metric:
+		return "km/h"
+	default:
+		return "mph"
+	}
+}
+
+// ConvertWindSpeed parses s as "<number> mph" (the format NWS's
+// ForecastPeriod.WindSpeed comes in) and returns just the number,
+// converted to u's wind speed unit. s is returned unchanged if its
+// leading token doesn't parse as a number.
+//
+// None of WeatherPoint, HourlyForecast, or DailyForecast carry a wind
+// speed field today, so this isn't wired into CLI output yet; it exists
+// for callers (e.g. a future provider-level wind field) that need the
+// conversion math without duplicating it.
+func ConvertWindSpeed(s string, u Units) string {
+	mph, _, _ := strings.Cut(strings.TrimSpace(s), " ")
+	f, err := strconv.ParseFloat(mph, 64)
+	if err != nil {
+		return s
+	}
+	switch u {
+	case SI:
+		f *= 0.44704
+	case Metric:
+		f *= 1.609344
+	}
+	return strconv.FormatFloat(f, 'f', 1, 64)
+}
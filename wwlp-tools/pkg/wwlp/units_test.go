@@ -0,0 +1,28 @@
+package wwlp
+
+import "testing"
+
+func TestConvertTemperatureFahrenheitToCelsius(t *testing.T) {
+	got := ConvertTemperature("32", Metric)
+	if got != "0" {
+		t.Fatalf("ConvertTemperature(32, Metric) = %q, want 0", got)
+	}
+	if ConvertTemperature("32", Imperial) != "32" {
+		t.Fatalf("ConvertTemperature(32, Imperial) should be a no-op")
+	}
+}
+
+func TestParseUnitsRejectsUnknown(t *testing.T) {
+	if _, err := ParseUnits("kelvin"); err == nil {
+		t.Fatalf("expected an error for an unsupported units value")
+	}
+}
+
+func TestLocalizeFallsBackForUnsupportedLocale(t *testing.T) {
+	if _, ok := Localize("Monday", "fr"); ok {
+		t.Fatalf("expected ok=false for an unimplemented locale")
+	}
+	if text, ok := Localize("Monday", DefaultLocale); !ok || text != "Monday" {
+		t.Fatalf("expected English to pass through unchanged, got %q, %v", text, ok)
+	}
+}
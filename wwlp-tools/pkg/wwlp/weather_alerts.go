@@ -1,6 +1,8 @@
 package wwlp
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -113,6 +115,68 @@ func LoadWeatherAlertsURL(counties string) ([]WeatherAlert, error) {
 	return LoadWeatherAlerts(resp.Body)
 }
 
+// LoadWeatherAlertsURLContext is LoadWeatherAlertsURL with a caller-supplied
+// context and ClientOptions (timeout, retries, backoff, transport, extra
+// headers), so request-scoped pipelines can cancel or bound the fetch.
+func LoadWeatherAlertsURLContext(ctx context.Context, counties string, opts ClientOptions) ([]WeatherAlert, error) {
+	data, _, _, _, err := fetchWeatherAlertsConditional(ctx, counties, opts, "", "")
+	if err != nil {
+		return nil, err
+	}
+	return LoadWeatherAlerts(bytes.NewReader(data))
+}
+
+// fetchWeatherAlertsConditional issues a GET for counties, sending
+// If-None-Match/If-Modified-Since when etag/lastModified are non-empty so
+// repeated polls (e.g. from SubscribeWeatherAlerts) can skip re-parsing an
+// unchanged response. notModified is true on a 304 response, in which case
+// data is nil and the caller should keep using its previous parse.
+func fetchWeatherAlertsConditional(ctx context.Context, counties string, opts ClientOptions, etag, lastModified string) (data []byte, newETag, newLastModified string, notModified bool, err error) {
+	u, err := url.Parse(weatherAlertsBaseURL)
+	if err != nil {
+		return nil, "", "", false, fmt.Errorf("parse base url: %w", err)
+	}
+	u.Path = weatherAlertsEndpoint
+	if counties != "" {
+		q := u.Query()
+		q.Set("counties", counties)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, "", "", false, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "*/*")
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9")
+	req.Header.Set("Origin", "https://www.wwlp.com")
+	req.Header.Set("Referer", "https://www.wwlp.com/")
+	req.Header.Set("User-Agent", defaultUserAgent)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastModified != "" {
+		req.Header.Set("If-Modified-Since", lastModified)
+	}
+
+	resp, err := doRequest(ctx, req, opts)
+	if err != nil {
+		return nil, "", "", false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), true, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, "", "", false, fmt.Errorf("http status: %s", resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", "", false, fmt.Errorf("read alerts: %w", err)
+	}
+	return body, resp.Header.Get("ETag"), resp.Header.Get("Last-Modified"), false, nil
+}
+
 func ParseWeatherAlertPayload(payload string) (*WeatherAlertPayload, error) {
 	if payload == "" {
 		return nil, nil
@@ -0,0 +1,126 @@
+package wwlp
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// websocketAcceptGUID is the fixed GUID RFC 6455 uses to derive
+// Sec-WebSocket-Accept from the client's Sec-WebSocket-Key.
+const websocketAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// WeatherAlertsWebSocketHandler streams SubscribeWeatherAlerts events to a
+// WebSocket client, one JSON-encoded AlertEvent per text frame, for
+// browser dashboards that prefer a persistent socket over SSE; upstream
+// errors are sent as plain-text frames holding the error message. It's a
+// minimal RFC 6455 server (text frames only) rather than a full
+// implementation, since this module has no WebSocket library dependency:
+// it never reads from the client, so client messages, close frames
+// included, are not handled.
+func WeatherAlertsWebSocketHandler(counties string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get("Sec-WebSocket-Key")
+		if key == "" {
+			http.Error(w, "expected websocket upgrade", http.StatusBadRequest)
+			return
+		}
+
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			http.Error(w, "hijack unsupported", http.StatusInternalServerError)
+			return
+		}
+		conn, rw, err := hijacker.Hijack()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer conn.Close()
+
+		accept := websocketAccept(key)
+		fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\n"+
+			"Upgrade: websocket\r\n"+
+			"Connection: Upgrade\r\n"+
+			"Sec-WebSocket-Accept: %s\r\n\r\n", accept)
+		if err := rw.Flush(); err != nil {
+			return
+		}
+
+		events, errs := SubscribeWeatherAlerts(r.Context(), counties, SubscribeOptions{})
+		for {
+			select {
+			case evt, ok := <-events:
+				if !ok {
+					return
+				}
+				data, err := json.Marshal(evt)
+				if err != nil {
+					continue
+				}
+				if err := writeWebSocketTextFrame(rw.Writer, data); err != nil {
+					return
+				}
+			case err, ok := <-errs:
+				if !ok {
+					continue
+				}
+				if writeErr := writeWebSocketTextFrame(rw.Writer, []byte(err.Error())); writeErr != nil {
+					return
+				}
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+}
+
+func websocketAccept(key string) string {
+	h := sha1.New()
+	h.Write([]byte(key))
+	h.Write([]byte(websocketAcceptGUID))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// writeWebSocketTextFrame writes payload as a single unmasked RFC 6455
+// text frame (opcode 0x1, FIN set). Server-to-client frames are never
+// masked.
+func writeWebSocketTextFrame(w *bufio.Writer, payload []byte) error {
+	if err := w.WriteByte(0x81); err != nil { // FIN=1, opcode=1 (text)
+		return err
+	}
+	if err := writeWebSocketLength(w, len(payload)); err != nil {
+		return err
+	}
+	if _, err := w.Write(payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+func writeWebSocketLength(w *bufio.Writer, n int) error {
+	switch {
+	case n <= 125:
+		return w.WriteByte(byte(n))
+	case n <= 0xFFFF:
+		if err := w.WriteByte(126); err != nil {
+			return err
+		}
+		var buf [2]byte
+		binary.BigEndian.PutUint16(buf[:], uint16(n))
+		_, err := w.Write(buf[:])
+		return err
+	default:
+		if err := w.WriteByte(127); err != nil {
+			return err
+		}
+		var buf [8]byte
+		binary.BigEndian.PutUint64(buf[:], uint64(n))
+		_, err := w.Write(buf[:])
+		return err
+	}
+}